@@ -0,0 +1,24 @@
+package adminapi
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// AdminURLWithBasePath returns the Admin API address with the base path appended to its path, for Kong Gateways
+// serving the Admin API under a path prefix (e.g. behind a reverse proxy). As all the Admin API requests (including
+// the ones made by deck and the DB-less /config pushes) are made relative to the address, they all include the prefix.
+// The address is returned unchanged when the base path is empty.
+func AdminURLWithBasePath(adminURL string, basePath string) (string, error) {
+	if basePath == "" || basePath == "/" {
+		return adminURL, nil
+	}
+	u, err := url.Parse(adminURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Admin API address %q: %w", adminURL, err)
+	}
+	if u.Scheme == unixSocketScheme {
+		return "", fmt.Errorf("base path can't be used with Unix domain socket address %q", adminURL)
+	}
+	return u.JoinPath(basePath).String(), nil
+}
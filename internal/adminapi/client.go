@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/kong/go-kong/kong"
 	"github.com/samber/lo"
@@ -24,7 +25,15 @@ type Client struct {
 	pluginSchemaStore   *util.PluginSchemaStore
 	isKonnect           bool
 	konnectControlPlane string
-	lastConfigSHA       []byte
+
+	// lastConfigSHA is the checksum of the last configuration successfully pushed to this particular Admin API.
+	// It's kept per client so that multiple Kong instances (or clusters) managed by a single process don't
+	// interfere with each other's change detection.
+	lastConfigSHA     []byte
+	lastConfigSHALock sync.RWMutex
+
+	// pushLock serializes configuration pushes to this particular Admin API.
+	pushLock sync.Mutex
 
 	// podRef (optional) describes the Pod that the Client communicates with.
 	podRef *k8stypes.NamespacedName
@@ -40,7 +49,9 @@ func NewClient(c *kong.Client) *Client {
 
 // NewTestClient creates a client for test purposes.
 func NewTestClient(address string) (*Client, error) {
-	kongClient, err := kong.NewTestClient(lo.ToPtr(address), &http.Client{})
+	kongClient, err := kong.NewTestClient(lo.ToPtr(address), &http.Client{
+		Transport: &HeaderRoundTripper{rt: http.DefaultTransport},
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -156,14 +167,24 @@ func (c *Client) KonnectControlPlane() string {
 
 // SetLastConfigSHA overrides last config SHA.
 func (c *Client) SetLastConfigSHA(s []byte) {
+	c.lastConfigSHALock.Lock()
+	defer c.lastConfigSHALock.Unlock()
 	c.lastConfigSHA = s
 }
 
 // LastConfigSHA returns a checksum of the last successful configuration push.
 func (c *Client) LastConfigSHA() []byte {
+	c.lastConfigSHALock.RLock()
+	defer c.lastConfigSHALock.RUnlock()
 	return c.lastConfigSHA
 }
 
+// PushLock returns the lock that serializes configuration pushes to this particular Admin API, so concurrent
+// pushes don't race with each other (e.g. pushing the same configuration twice).
+func (c *Client) PushLock() sync.Locker {
+	return &c.pushLock
+}
+
 // AttachPodReference allows attaching a Pod reference to the client. Should be used in case we know what Pod the client
 // will communicate with (e.g. when the gateway service discovery is used).
 func (c *Client) AttachPodReference(podNN k8stypes.NamespacedName) {
@@ -182,6 +203,7 @@ type ClientFactory struct {
 	workspace      string
 	httpClientOpts HTTPClientOpts
 	adminToken     string
+	basePath       string
 }
 
 func NewClientFactoryForWorkspace(workspace string, httpClientOpts HTTPClientOpts, adminToken string) ClientFactory {
@@ -192,12 +214,23 @@ func NewClientFactoryForWorkspace(workspace string, httpClientOpts HTTPClientOpt
 	}
 }
 
+// WithBasePath returns a copy of the factory creating clients for discovered Admin APIs served under the base path
+// (see AdminURLWithBasePath).
+func (cf ClientFactory) WithBasePath(basePath string) ClientFactory {
+	cf.basePath = basePath
+	return cf
+}
+
 func (cf ClientFactory) CreateAdminAPIClient(ctx context.Context, discoveredAdminAPI DiscoveredAdminAPI) (*Client, error) {
 	httpclient, err := MakeHTTPClient(&cf.httpClientOpts, cf.adminToken)
 	if err != nil {
 		return nil, err
 	}
-	cl, err := NewKongClientForWorkspace(ctx, discoveredAdminAPI.Address, cf.workspace, httpclient)
+	address, err := AdminURLWithBasePath(discoveredAdminAPI.Address, cf.basePath)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := NewKongClientForWorkspace(ctx, address, cf.workspace, httpclient)
 	if err != nil {
 		return nil, err
 	}
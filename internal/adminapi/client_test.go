@@ -36,3 +36,18 @@ func TestClientFactory_CreateAdminAPIClientAttachesPodReference(t *testing.T) {
 		Name:      "name",
 	}, ref)
 }
+
+func TestClient_LastConfigSHAIsTrackedPerClient(t *testing.T) {
+	first, err := adminapi.NewTestClient("localhost:8001")
+	require.NoError(t, err)
+	second, err := adminapi.NewTestClient("localhost:8002")
+	require.NoError(t, err)
+
+	first.SetLastConfigSHA([]byte("first-sha"))
+	require.Equal(t, []byte("first-sha"), first.LastConfigSHA())
+	require.Nil(t, second.LastConfigSHA(), "setting SHA on one client must not affect the other")
+
+	second.SetLastConfigSHA([]byte("second-sha"))
+	require.Equal(t, []byte("first-sha"), first.LastConfigSHA())
+	require.Equal(t, []byte("second-sha"), second.LastConfigSHA())
+}
@@ -0,0 +1,59 @@
+package adminapi
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// HeaderNameCorrelationID is the header carrying a correlation ID of Admin API requests, letting requests made
+	// in the scope of a single operation (e.g. a configuration update) be traced across the controller and Kong.
+	HeaderNameCorrelationID = "X-Correlation-ID"
+
+	// HeaderNameKongAdminRequestID is the header Kong (3.5+) returns its ID of an Admin API request in.
+	HeaderNameKongAdminRequestID = "X-Kong-Admin-Request-ID"
+)
+
+type correlationContextKey struct{}
+
+// correlation holds a correlation ID along with the ID Kong assigned to the last request made with it.
+type correlation struct {
+	id string
+
+	lock              sync.Mutex
+	lastKongRequestID string
+}
+
+// ContextWithCorrelationID returns a context carrying the correlation ID. Admin API requests made with the context
+// (or contexts derived from it) using an HTTP client created with MakeHTTPClient have the ID set in the
+// HeaderNameCorrelationID header.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationContextKey{}, &correlation{id: id})
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by the context, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	c, ok := ctx.Value(correlationContextKey{}).(*correlation)
+	if !ok {
+		return "", false
+	}
+	return c.id, true
+}
+
+// LastKongRequestIDFromContext returns the ID that Kong assigned to the last Admin API request made with the context
+// carrying a correlation ID, if Kong reported it.
+func LastKongRequestIDFromContext(ctx context.Context) (string, bool) {
+	c, ok := ctx.Value(correlationContextKey{}).(*correlation)
+	if !ok {
+		return "", false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lastKongRequestID, c.lastKongRequestID != ""
+}
+
+func (c *correlation) setLastKongRequestID(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lastKongRequestID = id
+}
@@ -0,0 +1,62 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// HeaderNameIfMatch is the header making an Admin API request conditional on the hash of the configuration Kong
+// currently holds. Kong itself doesn't evaluate it, it's a contract for a proxy in front of the Admin API that is
+// expected to reject the request with 412 Precondition Failed when the hash doesn't match, e.g. because
+// the configuration has been modified by another writer.
+const HeaderNameIfMatch = "If-Match"
+
+type expectedConfigHashContextKey struct{}
+
+// expectedConfigHash holds the hash of the configuration Kong is expected to hold along with whether a request
+// made with it was rejected due to the hash not matching.
+type expectedConfigHash struct {
+	hash string
+
+	lock               sync.Mutex
+	preconditionFailed bool
+}
+
+// ContextWithExpectedConfigHash returns a context carrying the hash of the configuration Kong is expected to hold.
+// Admin API requests made with the context using an HTTP client created with MakeHTTPClient have the hash set
+// (as an entity tag) in the HeaderNameIfMatch header.
+func ContextWithExpectedConfigHash(ctx context.Context, hash string) context.Context {
+	return context.WithValue(ctx, expectedConfigHashContextKey{}, &expectedConfigHash{hash: hash})
+}
+
+// ExpectedConfigHashFromContext returns the expected configuration hash carried by the context, if any.
+func ExpectedConfigHashFromContext(ctx context.Context) (string, bool) {
+	h, ok := ctx.Value(expectedConfigHashContextKey{}).(*expectedConfigHash)
+	if !ok {
+		return "", false
+	}
+	return h.hash, true
+}
+
+// PreconditionFailedFromContext tells whether an Admin API request made with the context carrying the expected
+// configuration hash was rejected with 412 Precondition Failed. It lets callers tell the rejection apart from other
+// failures regardless of how the response is turned into an error.
+func PreconditionFailedFromContext(ctx context.Context) bool {
+	h, ok := ctx.Value(expectedConfigHashContextKey{}).(*expectedConfigHash)
+	if !ok {
+		return false
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.preconditionFailed
+}
+
+func (h *expectedConfigHash) recordResponse(resp *http.Response) {
+	if resp == nil || resp.StatusCode != http.StatusPreconditionFailed {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.preconditionFailed = true
+}
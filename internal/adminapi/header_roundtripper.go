@@ -6,7 +6,12 @@ import (
 )
 
 // HeaderRoundTripper injects Headers into requests
-// made via RT.
+// made via RT. Headers already set on a request (e.g. Content-Type)
+// take precedence over the injected ones.
+// It also sets the correlation ID (see ContextWithCorrelationID), the idempotency key
+// (see ContextWithIdempotencyKey) and the expected configuration hash (see ContextWithExpectedConfigHash)
+// carried by a request's context, and records the request ID Kong responds with, whether it rejected a conditional
+// request and the response's status code (see ContextWithResponseStatus).
 type HeaderRoundTripper struct {
 	headers []string
 	rt      http.RoundTripper
@@ -18,8 +23,36 @@ func (t *HeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 	for _, s := range t.headers {
 		split := strings.SplitN(s, ":", 2)
 		if len(split) >= 2 {
+			if newRequest.Header.Get(split[0]) != "" {
+				continue
+			}
 			newRequest.Header[split[0]] = append([]string(nil), split[1])
 		}
 	}
-	return t.rt.RoundTrip(newRequest)
+
+	c, hasCorrelation := req.Context().Value(correlationContextKey{}).(*correlation)
+	if hasCorrelation {
+		newRequest.Header.Set(HeaderNameCorrelationID, c.id)
+	}
+	if key, ok := IdempotencyKeyFromContext(req.Context()); ok {
+		newRequest.Header.Set(HeaderNameIdempotencyKey, key)
+	}
+	h, hasExpectedConfigHash := req.Context().Value(expectedConfigHashContextKey{}).(*expectedConfigHash)
+	if hasExpectedConfigHash {
+		newRequest.Header.Set(HeaderNameIfMatch, `"`+h.hash+`"`)
+	}
+
+	resp, err := t.rt.RoundTrip(newRequest)
+	if hasCorrelation && resp != nil {
+		if kongRequestID := resp.Header.Get(HeaderNameKongAdminRequestID); kongRequestID != "" {
+			c.setLastKongRequestID(kongRequestID)
+		}
+	}
+	if hasExpectedConfigHash {
+		h.recordResponse(resp)
+	}
+	if s, ok := req.Context().Value(responseStatusContextKey{}).(*responseStatus); ok {
+		s.recordResponse(resp)
+	}
+	return resp, err
 }
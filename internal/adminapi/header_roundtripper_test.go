@@ -0,0 +1,197 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderRoundTripper(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: &HeaderRoundTripper{
+			headers: []string{"X-Request-Id:abc", "content-type:text/plain"},
+			rt:      http.DefaultTransport,
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/config", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Equal(t, []string{"abc"}, received.Values("X-Request-Id"), "custom header should be injected")
+	require.Equal(t, []string{"application/json"}, received.Values("Content-Type"), "header set on request should take precedence")
+}
+
+func TestHeaderRoundTripper_CorrelationID(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set(HeaderNameKongAdminRequestID, "kong-request-id")
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: &HeaderRoundTripper{rt: http.DefaultTransport},
+	}
+
+	t.Run("without correlation ID", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Empty(t, received.Values(HeaderNameCorrelationID))
+	})
+
+	t.Run("with correlation ID", func(t *testing.T) {
+		ctx := ContextWithCorrelationID(context.Background(), "correlation-id")
+		_, ok := LastKongRequestIDFromContext(ctx)
+		require.False(t, ok)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, []string{"correlation-id"}, received.Values(HeaderNameCorrelationID))
+		kongRequestID, ok := LastKongRequestIDFromContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, "kong-request-id", kongRequestID)
+	})
+}
+
+func TestHeaderRoundTripper_IdempotencyKey(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: &HeaderRoundTripper{rt: http.DefaultTransport},
+	}
+
+	t.Run("without idempotency key", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Empty(t, received.Values(HeaderNameIdempotencyKey))
+	})
+
+	t.Run("with idempotency key", func(t *testing.T) {
+		ctx := ContextWithIdempotencyKey(context.Background(), "idempotency-key")
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, []string{"idempotency-key"}, received.Values(HeaderNameIdempotencyKey))
+	})
+}
+
+func TestHeaderRoundTripper_ExpectedConfigHash(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: &HeaderRoundTripper{rt: http.DefaultTransport},
+	}
+
+	t.Run("without expected config hash", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Empty(t, received.Values(HeaderNameIfMatch))
+	})
+
+	t.Run("with expected config hash", func(t *testing.T) {
+		ctx := ContextWithExpectedConfigHash(context.Background(), "2b1a0e4b0f0b4a0e8f3c2d1e0a9b8c7d")
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, []string{`"2b1a0e4b0f0b4a0e8f3c2d1e0a9b8c7d"`}, received.Values(HeaderNameIfMatch))
+		require.False(t, PreconditionFailedFromContext(ctx))
+	})
+
+	t.Run("precondition failure is recorded", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+		}))
+		t.Cleanup(server.Close)
+
+		ctx := ContextWithExpectedConfigHash(context.Background(), "2b1a0e4b0f0b4a0e8f3c2d1e0a9b8c7d")
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.True(t, PreconditionFailedFromContext(ctx))
+	})
+}
+
+func TestHeaderRoundTripper_ResponseStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: &HeaderRoundTripper{rt: http.DefaultTransport},
+	}
+
+	t.Run("not recorded without response status in context", func(t *testing.T) {
+		ctx := context.Background()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		_, ok := ResponseStatusFromContext(ctx)
+		require.False(t, ok)
+	})
+
+	t.Run("recorded with response status in context", func(t *testing.T) {
+		ctx := ContextWithResponseStatus(context.Background())
+		_, ok := ResponseStatusFromContext(ctx)
+		require.False(t, ok, "no status should be reported before any response is received")
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/config", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		code, ok := ResponseStatusFromContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
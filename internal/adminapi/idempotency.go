@@ -0,0 +1,23 @@
+package adminapi
+
+import (
+	"context"
+)
+
+// HeaderNameIdempotencyKey is the header carrying an idempotency key of an Admin API request, letting a proxy
+// in front of Kong (or Kong itself) deduplicate requests (e.g. retried ones) that are meant to have the same effect.
+const HeaderNameIdempotencyKey = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns a context carrying the idempotency key. Admin API requests made with the context
+// using an HTTP client created with MakeHTTPClient have the key set in the HeaderNameIdempotencyKey header.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key carried by the context, if any.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok
+}
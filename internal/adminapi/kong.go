@@ -44,9 +44,18 @@ func (e KongGatewayUnsupportedVersionError) Error() string {
 // It ensures that the client is ready to be used by performing a status check, returns KongClientNotReadyError if not
 // or KongGatewayUnsupportedVersionError if it can't check Kong Gateway's version or it is not >= 3.4.1.
 // If the workspace does not already exist, NewKongClientForWorkspace will create it.
+// An adminURL in the unix:///path/to/admin.sock format makes the client communicate with the Admin API over
+// the Unix domain socket.
 func NewKongClientForWorkspace(
 	ctx context.Context, adminURL string, wsName string, httpClient *http.Client,
 ) (*Client, error) {
+	if socketPath, ok := unixSocketPath(adminURL); ok {
+		var err error
+		if httpClient, err = unixSocketHTTPClient(httpClient, socketPath); err != nil {
+			return nil, fmt.Errorf("creating HTTP client for Unix domain socket %s: %w", socketPath, err)
+		}
+	}
+
 	// Create the base client, and if no workspace was provided then return that.
 	client, err := kong.NewClient(kong.String(adminURL), httpClient)
 	if err != nil {
@@ -112,6 +121,9 @@ type HTTPClientOpts struct {
 	CACert string
 	// Array of headers added to every Admin API call.
 	Headers []string
+	// UserAgent is the User-Agent header set on every Admin API call (unless it's set in Headers).
+	// Go's default User-Agent is used when empty.
+	UserAgent string
 	// TLSClient is TLS client config.
 	TLSClient TLSClientConfig
 }
@@ -158,26 +170,35 @@ func MakeHTTPClient(opts *HTTPClientOpts, kongAdminToken string) (*http.Client,
 		tlsConfig.RootCAs = certPool
 	}
 
-	clientCertificate, err := tlsutil.ExtractClientCertificates(
-		[]byte(opts.TLSClient.Cert), opts.TLSClient.CertFile, []byte(opts.TLSClient.Key), opts.TLSClient.KeyFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract client certificates: %w", err)
-	}
-	if clientCertificate != nil {
-		tlsConfig.Certificates = append(tlsConfig.Certificates, *clientCertificate)
+	if opts.TLSClient.CertFile != "" && opts.TLSClient.KeyFile != "" {
+		// Client certificate provided in files is reloaded on change to support its rotation.
+		reloader, err := newClientCertificateReloader(opts.TLSClient.CertFile, opts.TLSClient.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract client certificates: %w", err)
+		}
+		tlsConfig.GetClientCertificate = reloader.GetClientCertificate
+	} else {
+		clientCertificate, err := tlsutil.ExtractClientCertificates(
+			[]byte(opts.TLSClient.Cert), opts.TLSClient.CertFile, []byte(opts.TLSClient.Key), opts.TLSClient.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract client certificates: %w", err)
+		}
+		if clientCertificate != nil {
+			tlsConfig.Certificates = append(tlsConfig.Certificates, *clientCertificate)
+		}
 	}
 
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig = &tlsConfig
 	return &http.Client{
 		Transport: &HeaderRoundTripper{
-			headers: prepareHeaders(opts.Headers, kongAdminToken),
+			headers: prepareHeaders(opts.Headers, kongAdminToken, opts.UserAgent),
 			rt:      transport,
 		},
 	}, nil
 }
 
-func prepareHeaders(headers []string, kongAdminToken string) []string {
+func prepareHeaders(headers []string, kongAdminToken string, userAgent string) []string {
 	if kongAdminToken != "" {
 		contains := lo.ContainsBy(headers, func(header string) bool {
 			return strings.HasPrefix(header, HeaderNameAdminToken+":")
@@ -187,5 +208,14 @@ func prepareHeaders(headers []string, kongAdminToken string) []string {
 			headers = append(headers, HeaderNameAdminToken+":"+kongAdminToken)
 		}
 	}
+	if userAgent != "" {
+		contains := lo.ContainsBy(headers, func(header string) bool {
+			return strings.HasPrefix(strings.ToLower(header), strings.ToLower(HeaderNameUserAgent)+":")
+		})
+
+		if !contains {
+			headers = append(headers, HeaderNameUserAgent+":"+userAgent)
+		}
+	}
 	return headers
 }
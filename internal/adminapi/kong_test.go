@@ -6,9 +6,11 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -104,6 +106,51 @@ func TestMakeHTTPClientWithTLSOptsAndFilePaths(t *testing.T) {
 	})
 }
 
+func TestMakeHTTPClientUserAgent(t *testing.T) {
+	testCases := []struct {
+		name              string
+		opts              adminapi.HTTPClientOpts
+		expectedUserAgent string
+	}{
+		{
+			name:              "user agent with instance id",
+			opts:              adminapi.HTTPClientOpts{UserAgent: adminapi.UserAgent("3.1.0", "instance-1")},
+			expectedUserAgent: "kong-ingress-controller/3.1.0 (instance-1)",
+		},
+		{
+			name:              "user agent without instance id",
+			opts:              adminapi.HTTPClientOpts{UserAgent: adminapi.UserAgent("3.1.0", "")},
+			expectedUserAgent: "kong-ingress-controller/3.1.0",
+		},
+		{
+			name: "user agent set with headers takes precedence",
+			opts: adminapi.HTTPClientOpts{
+				UserAgent: adminapi.UserAgent("3.1.0", ""),
+				Headers:   []string{"User-Agent:custom"},
+			},
+			expectedUserAgent: "custom",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var userAgent string
+			server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				userAgent = r.Header.Get("User-Agent")
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := adminapi.MakeHTTPClient(&tc.opts, "")
+			require.NoError(t, err)
+			resp, err := c.Get(server.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, tc.expectedUserAgent, userAgent)
+		})
+	}
+}
+
 func TestNewKongClientForWorkspace(t *testing.T) {
 	const testWorkspace = "workspace"
 
@@ -195,6 +242,118 @@ func TestNewKongClientForWorkspace(t *testing.T) {
 	}
 }
 
+func TestNewKongClientForWorkspace_UnixSocket(t *testing.T) {
+	const testWorkspace = "workspace"
+	socketPath := filepath.Join(t.TempDir(), "admin.sock")
+
+	adminAPIHandler := mocks.NewAdminAPIHandler(t, mocks.WithWorkspaceExists(true))
+	var adminToken string
+	adminAPIServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adminToken = r.Header.Get(adminapi.HeaderNameAdminToken)
+		adminAPIHandler.ServeHTTP(w, r)
+	}))
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	adminAPIServer.Listener = listener
+	adminAPIServer.Start()
+	t.Cleanup(adminAPIServer.Close)
+
+	httpClient, err := adminapi.MakeHTTPClient(&adminapi.HTTPClientOpts{}, "token")
+	require.NoError(t, err)
+
+	t.Run("requests are sent over the socket", func(t *testing.T) {
+		client, err := adminapi.NewKongClientForWorkspace(context.Background(), "unix://"+socketPath, testWorkspace, httpClient)
+		require.NoError(t, err)
+		require.Equal(t, "unix://"+socketPath, client.BaseRootURL(), "socket address should identify the client")
+		require.Equal(t, testWorkspace, client.AdminAPIClient().Workspace())
+		require.Equal(t, "token", adminToken, "headers should be injected")
+
+		_, err = client.AdminAPIClient().Status(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("missing socket", func(t *testing.T) {
+		missingSocketPath := filepath.Join(t.TempDir(), "missing.sock")
+		_, err := adminapi.NewKongClientForWorkspace(context.Background(), "unix://"+missingSocketPath, testWorkspace, httpClient)
+		require.IsType(t, adminapi.KongClientNotReadyError{}, err)
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr, "socket errors should be reported as network errors")
+	})
+
+	t.Run("HTTP addresses are not affected", func(t *testing.T) {
+		adminAPIServer := httptest.NewServer(mocks.NewAdminAPIHandler(t, mocks.WithWorkspaceExists(true)))
+		t.Cleanup(adminAPIServer.Close)
+
+		client, err := adminapi.NewKongClientForWorkspace(context.Background(), adminAPIServer.URL, testWorkspace, httpClient)
+		require.NoError(t, err)
+		require.Equal(t, adminAPIServer.URL, client.BaseRootURL())
+	})
+}
+
+func TestAdminURLWithBasePath(t *testing.T) {
+	testCases := []struct {
+		name        string
+		adminURL    string
+		basePath    string
+		expected    string
+		expectedErr bool
+	}{
+		{name: "no base path", adminURL: "https://10.0.0.1:8444", expected: "https://10.0.0.1:8444"},
+		{name: "root base path", adminURL: "https://10.0.0.1:8444", basePath: "/", expected: "https://10.0.0.1:8444"},
+		{name: "base path", adminURL: "https://10.0.0.1:8444", basePath: "/kong-admin", expected: "https://10.0.0.1:8444/kong-admin"},
+		{name: "base path without leading slash", adminURL: "https://10.0.0.1:8444", basePath: "kong-admin", expected: "https://10.0.0.1:8444/kong-admin"},
+		{name: "base path with trailing slash", adminURL: "https://10.0.0.1:8444/", basePath: "/kong-admin/", expected: "https://10.0.0.1:8444/kong-admin/"},
+		{name: "address with path", adminURL: "https://proxy.local/gateway", basePath: "/kong-admin", expected: "https://proxy.local/gateway/kong-admin"},
+		{name: "unix socket address", adminURL: "unix:///usr/local/kong/admin.sock", basePath: "/kong-admin", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address, err := adminapi.AdminURLWithBasePath(tc.adminURL, tc.basePath)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, address)
+		})
+	}
+}
+
+func TestNewKongClientForWorkspace_BasePath(t *testing.T) {
+	const basePath = "/kong-admin"
+	adminAPIHandler := mocks.NewAdminAPIHandler(t, mocks.WithWorkspaceExists(true))
+	var requestedPaths []string
+	adminAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		if !strings.HasPrefix(r.URL.Path, basePath+"/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/config") {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		r.URL.Path = strings.TrimPrefix(r.URL.Path, basePath)
+		adminAPIHandler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(adminAPIServer.Close)
+
+	address, err := adminapi.AdminURLWithBasePath(adminAPIServer.URL, basePath)
+	require.NoError(t, err)
+	client, err := adminapi.NewKongClientForWorkspace(context.Background(), address, "workspace", adminAPIServer.Client())
+	require.NoError(t, err)
+	require.Equal(t, address, client.BaseRootURL())
+
+	_, err = client.AdminAPIClient().ReloadDeclarativeRawConfig(context.Background(), strings.NewReader("{}"), true, false)
+	require.NoError(t, err)
+	require.NotEmpty(t, requestedPaths)
+	for _, path := range requestedPaths {
+		require.True(t, strings.HasPrefix(path, basePath+"/"), "request path %q should include the base path", path)
+	}
+	require.Contains(t, requestedPaths, basePath+"/workspace/config")
+}
+
 // validate spins up a test server with the given TLS configuration and verifies
 // whether the passed client can connect to it successfully.
 func validate(
@@ -0,0 +1,43 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type responseStatusContextKey struct{}
+
+// responseStatus holds the status code of the last response to a request made with a context carrying it.
+type responseStatus struct {
+	lock sync.Mutex
+	code int
+}
+
+// ContextWithResponseStatus returns a context recording the status code of responses to Admin API requests made with
+// it using an HTTP client created with MakeHTTPClient (see ResponseStatusFromContext). It lets callers learn
+// the status code of requests whose errors don't carry it (e.g. go-kong's ReloadDeclarativeRawConfig).
+func ContextWithResponseStatus(ctx context.Context) context.Context {
+	return context.WithValue(ctx, responseStatusContextKey{}, &responseStatus{})
+}
+
+// ResponseStatusFromContext returns the status code of the last response to an Admin API request made with the context
+// returned from ContextWithResponseStatus, if any response has been received.
+func ResponseStatusFromContext(ctx context.Context) (int, bool) {
+	s, ok := ctx.Value(responseStatusContextKey{}).(*responseStatus)
+	if !ok {
+		return 0, false
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.code, s.code != 0
+}
+
+func (s *responseStatus) recordResponse(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.code = resp.StatusCode
+}
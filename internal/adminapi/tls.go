@@ -1,5 +1,13 @@
 package adminapi
 
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
 // TLSClientConfig contains TLS client certificate and client key to be used when connecting with Admin APIs.
 // It's validated with manager.validateClientTLS before passing it further down. It guarantees that only the
 // allowed combinations of variables will be passed:
@@ -22,3 +30,70 @@ type TLSClientConfig struct {
 func (c TLSClientConfig) IsZero() bool {
 	return c == TLSClientConfig{}
 }
+
+// clientCertificateReloader provides a TLS client certificate loaded from files, reloading it whenever any of the
+// files changes, so that a rotated certificate is picked up without restarting the controller.
+// The certificate is used when establishing new connections only, so requests in flight are not affected by
+// a rotation. If the files fail to load (e.g. when only one of them has been replaced yet), the previously loaded
+// certificate keeps being used until they load successfully.
+type clientCertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	lock        sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// newClientCertificateReloader returns a clientCertificateReloader with the certificate loaded from the files.
+func newClientCertificateReloader(certFile, keyFile string) (*clientCertificateReloader, error) {
+	r := &clientCertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := r.reloadIfChanged(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetClientCertificate can be used as tls.Config's GetClientCertificate.
+func (r *clientCertificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.reloadIfChangedLocked(); err != nil && r.cert == nil {
+		return nil, err
+	}
+	return r.cert, nil
+}
+
+func (r *clientCertificateReloader) reloadIfChanged() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.reloadIfChangedLocked()
+}
+
+func (r *clientCertificateReloader) reloadIfChangedLocked() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("failed to stat client certificate file %s: %w", r.certFile, err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to stat client key file %s: %w", r.keyFile, err)
+	}
+	if r.cert != nil && certInfo.ModTime().Equal(r.certModTime) && keyInfo.ModTime().Equal(r.keyModTime) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	r.cert = &cert
+	r.certModTime = certInfo.ModTime()
+	r.keyModTime = keyInfo.ModTime()
+	return nil
+}
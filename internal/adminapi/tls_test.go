@@ -0,0 +1,65 @@
+package adminapi
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/test/helpers/certificate"
+)
+
+func TestClientCertificateReloader(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		certFile = filepath.Join(dir, "tls.crt")
+		keyFile  = filepath.Join(dir, "tls.key")
+		modTime  = time.Now()
+	)
+	writeFiles := func(t *testing.T, cert, key []byte) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(certFile, cert, 0o600))
+		require.NoError(t, os.WriteFile(keyFile, key, 0o600))
+		// Make sure modification times change even on filesystems with a coarse timestamp resolution.
+		modTime = modTime.Add(time.Second)
+		require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+		require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	}
+	requireCert := func(t *testing.T, r *clientCertificateReloader, expectedCertPEM, expectedKeyPEM []byte) {
+		t.Helper()
+		expected, err := tls.X509KeyPair(expectedCertPEM, expectedKeyPEM)
+		require.NoError(t, err)
+		cert, err := r.GetClientCertificate(&tls.CertificateRequestInfo{})
+		require.NoError(t, err)
+		require.Equal(t, expected.Certificate, cert.Certificate)
+	}
+
+	firstCert, firstKey := certificate.MustGenerateSelfSignedCertPEMFormat()
+	writeFiles(t, firstCert, firstKey)
+	r, err := newClientCertificateReloader(certFile, keyFile)
+	require.NoError(t, err)
+	requireCert(t, r, firstCert, firstKey)
+
+	t.Log("rotating the certificate")
+	secondCert, secondKey := certificate.MustGenerateSelfSignedCertPEMFormat()
+	writeFiles(t, secondCert, secondKey)
+	requireCert(t, r, secondCert, secondKey)
+
+	t.Log("replacing the certificate only, as if the rotation was in progress")
+	thirdCert, thirdKey := certificate.MustGenerateSelfSignedCertPEMFormat()
+	writeFiles(t, thirdCert, secondKey)
+	requireCert(t, r, secondCert, secondKey)
+
+	t.Log("replacing the key as well, completing the rotation")
+	writeFiles(t, thirdCert, thirdKey)
+	requireCert(t, r, thirdCert, thirdKey)
+}
+
+func TestClientCertificateReloader_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, err := newClientCertificateReloader(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"))
+	require.Error(t, err)
+}
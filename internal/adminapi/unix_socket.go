@@ -0,0 +1,102 @@
+package adminapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// unixSocketScheme is the scheme of Admin API addresses pointing to a Unix domain socket, e.g.
+// unix:///usr/local/kong/admin.sock.
+const unixSocketScheme = "unix"
+
+// unixSocketPath returns the path of the socket when the Admin API address points to a Unix domain socket.
+func unixSocketPath(adminURL string) (string, bool) {
+	u, err := url.Parse(adminURL)
+	if err != nil || u.Scheme != unixSocketScheme || u.Path == "" {
+		return "", false
+	}
+	return u.Path, true
+}
+
+// unixSocketHTTPClient returns a copy of the HTTP client that sends requests to unix:// URLs over the socket.
+// The base URL of the Admin API is kept as is, so that the socket address identifies the Kong instance (e.g. in
+// metrics and logs) the same way an HTTP address does.
+func unixSocketHTTPClient(httpClient *http.Client, socketPath string) (*http.Client, error) {
+	c := http.Client{}
+	if httpClient != nil {
+		c = *httpClient
+	}
+	rt, err := withUnixSocketDialer(c.Transport, socketPath)
+	if err != nil {
+		return nil, err
+	}
+	c.Transport = rt
+	return &c, nil
+}
+
+// withUnixSocketDialer replaces the *http.Transport at the bottom of the round tripper chain with its copy dialing
+// the socket.
+func withUnixSocketDialer(rt http.RoundTripper, socketPath string) (http.RoundTripper, error) {
+	switch rt := rt.(type) {
+	case nil:
+		return newUnixSocketRoundTripper(http.DefaultTransport.(*http.Transport), socketPath), nil
+	case *http.Transport:
+		return newUnixSocketRoundTripper(rt, socketPath), nil
+	case *HeaderRoundTripper:
+		inner, err := withUnixSocketDialer(rt.rt, socketPath)
+		if err != nil {
+			return nil, err
+		}
+		return &HeaderRoundTripper{headers: rt.headers, rt: inner}, nil
+	default:
+		return nil, fmt.Errorf("HTTP transport %T can't be used with a Unix domain socket address", rt)
+	}
+}
+
+// unixSocketRoundTripper sends requests to unix:// URLs over a Unix domain socket. The socket path prefixing the URL
+// path is stripped, so that e.g. unix:///usr/local/kong/admin.sock/status is sent as GET /status.
+type unixSocketRoundTripper struct {
+	socketPath string
+	rt         http.RoundTripper
+}
+
+func newUnixSocketRoundTripper(transport *http.Transport, socketPath string) *unixSocketRoundTripper {
+	transport = transport.Clone()
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+	return &unixSocketRoundTripper{
+		socketPath: socketPath,
+		rt:         transport,
+	}
+}
+
+// RoundTrip satisfies the RoundTripper interface.
+func (t *unixSocketRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != unixSocketScheme {
+		return t.rt.RoundTrip(req)
+	}
+
+	newRequest := req.Clone(req.Context())
+	newRequest.URL.Scheme = "http"
+	// The host is only used in the Host header as the connection is made to the socket regardless of it.
+	newRequest.URL.Host = "localhost"
+	newRequest.Host = ""
+	newRequest.URL.Path = ensureLeadingSlash(strings.TrimPrefix(req.URL.Path, t.socketPath))
+	if req.URL.RawPath != "" {
+		newRequest.URL.RawPath = ensureLeadingSlash(strings.TrimPrefix(req.URL.RawPath, t.socketPath))
+	}
+	return t.rt.RoundTrip(newRequest)
+}
+
+func ensureLeadingSlash(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
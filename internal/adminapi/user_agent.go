@@ -0,0 +1,21 @@
+package adminapi
+
+import "fmt"
+
+const (
+	// HeaderNameUserAgent is the header identifying the controller in Admin API requests.
+	HeaderNameUserAgent = "User-Agent"
+
+	userAgentProduct = "kong-ingress-controller"
+)
+
+// UserAgent returns a User-Agent identifying the controller's version and, if not empty, its instance
+// (e.g. "kong-ingress-controller/3.1.0 (instance-1)"), letting Admin API access logs tell which controller
+// made a request.
+func UserAgent(controllerVersion, instanceID string) string {
+	userAgent := fmt.Sprintf("%s/%s", userAgentProduct, controllerVersion)
+	if instanceID != "" {
+		userAgent = fmt.Sprintf("%s (%s)", userAgent, instanceID)
+	}
+	return userAgent
+}
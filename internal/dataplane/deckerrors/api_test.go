@@ -2,6 +2,7 @@ package deckerrors_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -57,3 +58,181 @@ func TestExtractAPIErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidationErr(t *testing.T) {
+	var (
+		genericErr    = errors.New("not an api error")
+		validationErr = kong.NewAPIError(http.StatusBadRequest, "schema violation")
+		conflictErr   = kong.NewAPIError(http.StatusConflict, "conflict")
+	)
+
+	testCases := []struct {
+		name     string
+		input    error
+		expected bool
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: false,
+		},
+		{
+			name:     "generic error",
+			input:    genericErr,
+			expected: false,
+		},
+		{
+			name:     "non-validation api error",
+			input:    conflictErr,
+			expected: false,
+		},
+		{
+			name:     "validation api error",
+			input:    validationErr,
+			expected: true,
+		},
+		{
+			name:     "deck array of errors with a validation api error among other ones",
+			input:    deckutils.ErrArray{Errors: []error{genericErr, conflictErr, validationErr}},
+			expected: true,
+		},
+		{
+			name:     "configuration push error response",
+			input:    deckerrors.ConfigStatusError{StatusCode: http.StatusBadRequest, Err: genericErr},
+			expected: true,
+		},
+		{
+			name:     "wrapped schema validation error",
+			input:    fmt.Errorf("pushing: %w", deckerrors.SchemaValidationError{Problems: []string{"invalid"}}),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, deckerrors.IsValidationErr(tc.input))
+		})
+	}
+}
+
+func TestIsAuthErr(t *testing.T) {
+	var (
+		genericErr      = errors.New("not an api error")
+		unauthorizedErr = kong.NewAPIError(http.StatusUnauthorized, "unauthorized")
+		forbiddenErr    = kong.NewAPIError(http.StatusForbidden, "forbidden")
+		validationErr   = kong.NewAPIError(http.StatusBadRequest, "schema violation")
+	)
+
+	testCases := []struct {
+		name     string
+		input    error
+		expected bool
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: false,
+		},
+		{
+			name:     "generic error",
+			input:    genericErr,
+			expected: false,
+		},
+		{
+			name:     "non-auth api error",
+			input:    validationErr,
+			expected: false,
+		},
+		{
+			name:     "unauthorized api error",
+			input:    unauthorizedErr,
+			expected: true,
+		},
+		{
+			name:     "forbidden api error",
+			input:    forbiddenErr,
+			expected: true,
+		},
+		{
+			name:     "deck array of errors with an auth api error among other ones",
+			input:    deckutils.ErrArray{Errors: []error{genericErr, validationErr, forbiddenErr}},
+			expected: true,
+		},
+		{
+			name:     "configuration push error response",
+			input:    deckerrors.ConfigStatusError{StatusCode: http.StatusUnauthorized, Err: genericErr},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, deckerrors.IsAuthErr(tc.input))
+		})
+	}
+}
+
+func TestIsConflictErr(t *testing.T) {
+	var (
+		genericErr     = errors.New("not an api error")
+		apiConflictErr = kong.NewAPIError(http.StatusConflict, "conflict")
+		configConflict = deckerrors.ConfigConflictError{Err: errors.New("duplicate route name")}
+	)
+
+	testCases := []struct {
+		name                   string
+		input                  error
+		expectedConfigConflict bool
+		expectedAPIConflict    bool
+	}{
+		{
+			name:  "nil",
+			input: nil,
+		},
+		{
+			name:  "generic error",
+			input: genericErr,
+		},
+		{
+			name:                "api conflict error",
+			input:               apiConflictErr,
+			expectedAPIConflict: true,
+		},
+		{
+			name:                "deck array of errors with an api conflict error among other ones",
+			input:               deckutils.ErrArray{Errors: []error{genericErr, apiConflictErr}},
+			expectedAPIConflict: true,
+		},
+		{
+			name:                   "config conflict error",
+			input:                  configConflict,
+			expectedConfigConflict: true,
+		},
+		{
+			name:                   "wrapped config conflict error",
+			input:                  fmt.Errorf("building target state: %w", configConflict),
+			expectedConfigConflict: true,
+		},
+		{
+			name:                   "deck array of errors with a config conflict error among other ones",
+			input:                  deckutils.ErrArray{Errors: []error{genericErr, configConflict}},
+			expectedConfigConflict: true,
+		},
+		{
+			name: "nested deck arrays of errors with a wrapped config conflict error",
+			input: fmt.Errorf("syncing: %w", deckutils.ErrArray{Errors: []error{
+				genericErr,
+				deckutils.ErrArray{Errors: []error{fmt.Errorf("building target state: %w", configConflict)}},
+			}}),
+			expectedConfigConflict: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedConfigConflict, deckerrors.IsConfigConflictErr(tc.input))
+			require.Equal(t, tc.expectedAPIConflict, deckerrors.IsAPIConflictErr(tc.input))
+			require.Equal(t, tc.expectedConfigConflict || tc.expectedAPIConflict, deckerrors.IsConflictErr(tc.input))
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package deckerrors
+
+import (
+	"net/http"
+)
+
+// IsAuthErr tells whether the error is a Kong Admin API error caused by
+// missing or invalid credentials (i.e. 401 Unauthorized or 403 Forbidden).
+func IsAuthErr(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized, http.StatusForbidden)
+}
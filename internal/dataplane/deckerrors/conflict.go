@@ -2,10 +2,10 @@ package deckerrors
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	deckutils "github.com/kong/deck/utils"
-	"github.com/kong/go-kong/kong"
 )
 
 // ConfigConflictError is an error used to wrap deck config conflict errors
@@ -27,17 +27,44 @@ func (e ConfigConflictError) Unwrap() error {
 	return e.Err
 }
 
+// ContentTransformError is an error used to wrap errors returned from custom
+// transformations of the generated deck file.Content.
+type ContentTransformError struct {
+	Err error
+}
+
+func (e ContentTransformError) Error() string {
+	return fmt.Sprintf("transforming configuration: %v", e.Err)
+}
+
+func (e ContentTransformError) Is(err error) bool {
+	_, ok := err.(ContentTransformError)
+	return ok
+}
+
+func (e ContentTransformError) Unwrap() error {
+	return e.Err
+}
+
+// IsConflictErr tells whether the error is a conflict of any kind, i.e. either a conflict found in the configuration
+// (see IsConfigConflictErr) or one reported by Kong's Admin API (see IsAPIConflictErr).
 func IsConflictErr(err error) bool {
-	var apiErr *kong.APIError
-	if errors.As(err, &apiErr) && apiErr.Code() == http.StatusConflict ||
-		errors.Is(err, ConfigConflictError{}) {
+	return IsConfigConflictErr(err) || IsAPIConflictErr(err)
+}
+
+// IsConfigConflictErr tells whether the error is a conflict found by decK while transforming the configuration
+// (see ConfigConflictError). It indicates a problem with the generated configuration (e.g. entities with duplicate
+// identifiers) rather than with concurrent changes of Kong's configuration.
+func IsConfigConflictErr(err error) bool {
+	if errors.Is(err, ConfigConflictError{}) {
 		return true
 	}
 
+	// deckutils.ErrArray doesn't unwrap to the errors it holds, hence they're checked one by one.
 	var deckErrArray deckutils.ErrArray
 	if errors.As(err, &deckErrArray) {
 		for _, err := range deckErrArray.Errors {
-			if IsConflictErr(err) {
+			if IsConfigConflictErr(err) {
 				return true
 			}
 		}
@@ -45,3 +72,9 @@ func IsConflictErr(err error) bool {
 
 	return false
 }
+
+// IsAPIConflictErr tells whether the error was caused by an Admin API 409 Conflict response, e.g. caused by
+// another client changing Kong's configuration concurrently.
+func IsAPIConflictErr(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
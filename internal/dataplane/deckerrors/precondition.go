@@ -0,0 +1,29 @@
+package deckerrors
+
+import (
+	"fmt"
+)
+
+// ConfigPreconditionFailedError is returned when a conditional configuration push is rejected (i.e. the Admin API,
+// or rather a proxy in front of it, responds with 412 Precondition Failed) as the hash of the configuration it holds doesn't match the expected one, i.e.
+// the configuration has been modified by another writer since it was last pushed.
+type ConfigPreconditionFailedError struct {
+	// Expected is the hash of the configuration Kong was expected to hold.
+	Expected string
+	Err      error
+}
+
+func (e ConfigPreconditionFailedError) Error() string {
+	return fmt.Sprintf("configuration held by Kong doesn't match the expected hash (%q), it has been modified concurrently: %v",
+		e.Expected, e.Err,
+	)
+}
+
+func (e ConfigPreconditionFailedError) Is(err error) bool {
+	_, ok := err.(ConfigPreconditionFailedError)
+	return ok
+}
+
+func (e ConfigPreconditionFailedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,23 @@
+package deckerrors
+
+import (
+	"fmt"
+)
+
+// ConfigTooLargeError is returned when a configuration exceeds the maximum size allowed to be pushed to Kong.
+type ConfigTooLargeError struct {
+	// Size is the (estimated) size of the configuration in bytes. It may be only a lower bound of the actual size
+	// as the serialization may be aborted as soon as the limit is exceeded.
+	Size int
+	// Limit is the maximum allowed size of the configuration in bytes.
+	Limit int
+}
+
+func (e ConfigTooLargeError) Error() string {
+	return fmt.Sprintf("configuration size (%d bytes) exceeds the limit of %d bytes", e.Size, e.Limit)
+}
+
+func (e ConfigTooLargeError) Is(err error) bool {
+	_, ok := err.(ConfigTooLargeError)
+	return ok
+}
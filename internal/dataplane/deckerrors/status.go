@@ -0,0 +1,44 @@
+package deckerrors
+
+import (
+	"errors"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+)
+
+// ConfigStatusError is returned when Kong responds to a DB-less configuration push (POST /config) with an error
+// status code. Errors returned from go-kong's ReloadDeclarativeRawConfig don't carry the status code (unlike
+// kong.APIError), so it's attached to them for the errors to be classified the same way as other Admin API errors.
+type ConfigStatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e ConfigStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ConfigStatusError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCodes returns HTTP status codes of Admin API responses the error was caused by, as carried by
+// kong.APIErrors (see ExtractAPIErrors) or ConfigStatusError.
+func StatusCodes(err error) []int {
+	codes := lo.Map(ExtractAPIErrors(err), func(apiErr *kong.APIError, _ int) int {
+		return apiErr.Code()
+	})
+	var statusErr ConfigStatusError
+	if errors.As(err, &statusErr) {
+		codes = append(codes, statusErr.StatusCode)
+	}
+	return codes
+}
+
+// hasStatusCode tells whether the error was caused by an Admin API response with one of the given status codes.
+func hasStatusCode(err error, codes ...int) bool {
+	return lo.ContainsBy(StatusCodes(err), func(code int) bool {
+		return lo.Contains(codes, code)
+	})
+}
@@ -0,0 +1,11 @@
+package deckerrors
+
+import (
+	"net/http"
+)
+
+// IsUnavailableErr tells whether the error is caused by the Admin API being temporarily unable to serve requests
+// (i.e. 503 Service Unavailable), as returned e.g. by Kong restarting during a rolling upgrade.
+func IsUnavailableErr(err error) bool {
+	return hasStatusCode(err, http.StatusServiceUnavailable)
+}
@@ -0,0 +1,40 @@
+package deckerrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// IsValidationErr tells whether the error is a Kong Admin API error caused by
+// the configuration failing schema validation (i.e. 400 Bad Request), SchemaValidationError
+// or CertificateValidationError.
+func IsValidationErr(err error) bool {
+	return hasStatusCode(err, http.StatusBadRequest) ||
+		errors.As(err, &SchemaValidationError{}) ||
+		errors.As(err, &CertificateValidationError{})
+}
+
+// SchemaValidationError is returned when configuration fails validation against Kong's entity schemas before
+// being pushed.
+type SchemaValidationError struct {
+	// Problems describe the particular violations, e.g. "plugin rate-limiting: config.minute: expected a number".
+	Problems []string
+}
+
+func (e SchemaValidationError) Error() string {
+	return fmt.Sprintf("configuration failed schema validation: %s", strings.Join(e.Problems, "; "))
+}
+
+// CertificateValidationError is returned when certificates in the configuration fail validation before being pushed,
+// e.g. when a certificate can't be parsed or doesn't match its private key.
+type CertificateValidationError struct {
+	// Problems describe the particular invalid certificates, e.g.
+	// "certificate from Secret default/tls: tls: private key does not match public key".
+	Problems []string
+}
+
+func (e CertificateValidationError) Error() string {
+	return fmt.Sprintf("configuration failed certificate validation: %s", strings.Join(e.Problems, "; "))
+}
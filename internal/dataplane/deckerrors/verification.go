@@ -0,0 +1,25 @@
+package deckerrors
+
+import (
+	"fmt"
+)
+
+// ConfigVerificationError is returned when the configuration hash reported by Kong after a configuration push
+// doesn't match the hash of the pushed configuration.
+type ConfigVerificationError struct {
+	// Expected is the hash of the pushed configuration.
+	Expected string
+	// Actual is the hash of the configuration reported by Kong.
+	Actual string
+}
+
+func (e ConfigVerificationError) Error() string {
+	return fmt.Sprintf("configuration hash reported by Kong (%q) doesn't match the pushed configuration's hash (%q)",
+		e.Actual, e.Expected,
+	)
+}
+
+func (e ConfigVerificationError) Is(err error) bool {
+	_, ok := err.(ConfigVerificationError)
+	return ok
+}
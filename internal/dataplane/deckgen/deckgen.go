@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 )
 
 // GenerateSHA generates a SHA256 checksum of targetContent, with the purpose
@@ -84,3 +85,65 @@ func IsContentEmpty(content *file.Content) bool {
 		),
 	)
 }
+
+// CountEntities returns the number of Kong entities (including the nested ones, e.g. routes of services)
+// in the content.
+func CountEntities(content *file.Content) int {
+	return lo.Sum(lo.Values(CountEntitiesByType(content)))
+}
+
+// CountEntitiesByType returns numbers of Kong entities (including the nested ones, e.g. routes of services)
+// in the content keyed by their type. Types are named after decK's declarative configuration keys and all of
+// them are present in the result, even if there are no entities of a type.
+func CountEntitiesByType(content *file.Content) map[string]int {
+	counts := map[string]int{
+		"services":              len(content.Services),
+		"routes":                len(content.Routes),
+		"plugins":               len(content.Plugins),
+		"consumers":             len(content.Consumers),
+		"consumer_groups":       len(content.ConsumerGroups),
+		"upstreams":             len(content.Upstreams),
+		"targets":               0,
+		"certificates":          len(content.Certificates),
+		"snis":                  0,
+		"ca_certificates":       len(content.CACertificates),
+		"vaults":                len(content.Vaults),
+		"keyauth_credentials":   0,
+		"hmacauth_credentials":  0,
+		"jwt_secrets":           0,
+		"basicauth_credentials": 0,
+		"oauth2_credentials":    0,
+		"acls":                  0,
+		"mtls_auth_credentials": 0,
+	}
+	for _, s := range content.Services {
+		counts["plugins"] += len(s.Plugins)
+		counts["routes"] += len(s.Routes)
+		for _, r := range s.Routes {
+			counts["plugins"] += len(r.Plugins)
+		}
+	}
+	for _, r := range content.Routes {
+		counts["plugins"] += len(r.Plugins)
+	}
+	for _, c := range content.Consumers {
+		counts["plugins"] += len(c.Plugins)
+		counts["keyauth_credentials"] += len(c.KeyAuths)
+		counts["hmacauth_credentials"] += len(c.HMACAuths)
+		counts["jwt_secrets"] += len(c.JWTAuths)
+		counts["basicauth_credentials"] += len(c.BasicAuths)
+		counts["oauth2_credentials"] += len(c.Oauth2Creds)
+		counts["acls"] += len(c.ACLGroups)
+		counts["mtls_auth_credentials"] += len(c.MTLSAuths)
+	}
+	for _, cg := range content.ConsumerGroups {
+		counts["plugins"] += len(cg.Plugins)
+	}
+	for _, u := range content.Upstreams {
+		counts["targets"] += len(u.Targets)
+	}
+	for _, c := range content.Certificates {
+		counts["snis"] += len(c.SNIs)
+	}
+	return counts
+}
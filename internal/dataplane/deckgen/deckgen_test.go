@@ -341,3 +341,43 @@ func TestIsContentEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestCountEntities(t *testing.T) {
+	require.Zero(t, deckgen.CountEntities(&file.Content{FormatVersion: "3.0"}))
+
+	content := &file.Content{
+		Services: []file.FService{
+			{
+				Service: kong.Service{Name: kong.String("service")},
+				Routes: []*file.FRoute{
+					{
+						Route:   kong.Route{Name: kong.String("route")},
+						Plugins: []*file.FPlugin{{Plugin: kong.Plugin{Name: kong.String("plugin")}}},
+					},
+				},
+			},
+		},
+		Consumers: []file.FConsumer{
+			{
+				Consumer: kong.Consumer{Username: kong.String("consumer")},
+				KeyAuths: []*kong.KeyAuth{{Key: kong.String("key")}},
+			},
+		},
+		Certificates: []file.FCertificate{
+			{SNIs: []kong.SNI{{Name: kong.String("example.com")}}},
+		},
+		Plugins: []file.FPlugin{{Plugin: kong.Plugin{Name: kong.String("global-plugin")}}},
+	}
+	require.Equal(t, 8, deckgen.CountEntities(content))
+
+	counts := deckgen.CountEntitiesByType(content)
+	require.Equal(t, 1, counts["services"])
+	require.Equal(t, 1, counts["routes"])
+	require.Equal(t, 2, counts["plugins"])
+	require.Equal(t, 1, counts["consumers"])
+	require.Equal(t, 1, counts["keyauth_credentials"])
+	require.Equal(t, 1, counts["certificates"])
+	require.Equal(t, 1, counts["snis"])
+	require.Contains(t, counts, "upstreams", "types with no entities should be present as well")
+	require.Zero(t, counts["upstreams"])
+}
@@ -0,0 +1,81 @@
+package deckgen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+)
+
+// NormalizeContentOrder sorts entities of every type in the content (including the nested ones, e.g. routes
+// of services) by their identifying fields, so that logically identical configurations are serialized (and hashed
+// with GenerateSHA) identically, regardless of the order their entities were generated in.
+// Entities are sorted in the same (descending) order as ToDeckContent sorts them, so its output is left unchanged.
+func NormalizeContentOrder(content *file.Content) {
+	for i := range content.Services {
+		s := &content.Services[i]
+		sortPlugins(s.Plugins)
+		normalizeRoutesOrder(s.Routes)
+	}
+	sortDesc(content.Services, func(s file.FService) *string { return s.Name })
+	for _, r := range content.Routes {
+		sortPlugins(r.Plugins)
+	}
+	sortDesc(content.Routes, func(r file.FRoute) *string { return r.Name })
+
+	sortDesc(content.Plugins, func(p file.FPlugin) *string { return lo.ToPtr(PluginString(p)) })
+
+	for i := range content.Consumers {
+		c := &content.Consumers[i]
+		sortPlugins(c.Plugins)
+		sortDesc(c.KeyAuths, func(a *kong.KeyAuth) *string { return a.Key })
+		sortDesc(c.HMACAuths, func(a *kong.HMACAuth) *string { return a.Username })
+		sortDesc(c.JWTAuths, func(a *kong.JWTAuth) *string { return a.Key })
+		sortDesc(c.BasicAuths, func(a *kong.BasicAuth) *string { return a.Username })
+		sortDesc(c.Oauth2Creds, func(a *kong.Oauth2Credential) *string { return a.ClientID })
+		sortDesc(c.ACLGroups, func(a *kong.ACLGroup) *string { return a.Group })
+		sortDesc(c.MTLSAuths, func(a *kong.MTLSAuth) *string { return a.SubjectName })
+		sortDesc(c.Groups, func(cg *kong.ConsumerGroup) *string { return cg.Name })
+	}
+	sortDesc(content.Consumers, func(c file.FConsumer) *string { return c.Username })
+
+	for i := range content.ConsumerGroups {
+		cg := &content.ConsumerGroups[i]
+		sortDesc(cg.Consumers, func(c *kong.Consumer) *string { return c.Username })
+		sortDesc(cg.Plugins, func(p *kong.ConsumerGroupPlugin) *string { return p.Name })
+	}
+	sortDesc(content.ConsumerGroups, func(cg file.FConsumerGroupObject) *string { return cg.Name })
+
+	for i := range content.Upstreams {
+		sortDesc(content.Upstreams[i].Targets, func(t *file.FTarget) *string { return t.Target.Target })
+	}
+	sortDesc(content.Upstreams, func(u file.FUpstream) *string { return u.Name })
+
+	for i := range content.Certificates {
+		sortDesc(content.Certificates[i].SNIs, func(sni kong.SNI) *string { return sni.Name })
+	}
+	sortDesc(content.Certificates, func(c file.FCertificate) *string { return c.Cert })
+	sortDesc(content.CACertificates, func(c file.FCACertificate) *string { return c.Cert })
+	sortDesc(content.Licenses, func(l file.FLicense) *string { return l.Payload })
+	sortDesc(content.Vaults, func(v file.FVault) *string { return v.Prefix })
+}
+
+func normalizeRoutesOrder(routes []*file.FRoute) {
+	for _, r := range routes {
+		sortPlugins(r.Plugins)
+	}
+	sortDesc(routes, func(r *file.FRoute) *string { return r.Name })
+}
+
+func sortPlugins(plugins []*file.FPlugin) {
+	sortDesc(plugins, func(p *file.FPlugin) *string { return p.Name })
+}
+
+// sortDesc stably sorts entities by the key in descending order. Unset keys are treated as empty.
+func sortDesc[T any](entities []T, key func(T) *string) {
+	sort.SliceStable(entities, func(i, j int) bool {
+		return strings.Compare(lo.FromPtr(key(entities[i])), lo.FromPtr(key(entities[j]))) > 0
+	})
+}
@@ -0,0 +1,145 @@
+package deckgen_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+)
+
+func TestNormalizeContentOrder_StableSHA(t *testing.T) {
+	newContent := func() *file.Content {
+		return &file.Content{
+			FormatVersion: "3.0",
+			Services: []file.FService{
+				{
+					Service: kong.Service{Name: kong.String("service-a")},
+					Routes: []*file.FRoute{
+						{Route: kong.Route{Name: kong.String("route-a-1")}},
+						{Route: kong.Route{Name: kong.String("route-a-2")}},
+						{Route: kong.Route{Name: kong.String("route-a-3")}},
+					},
+					Plugins: []*file.FPlugin{
+						{Plugin: kong.Plugin{Name: kong.String("cors")}},
+						{Plugin: kong.Plugin{Name: kong.String("rate-limiting")}},
+					},
+				},
+				{Service: kong.Service{Name: kong.String("service-b")}},
+				{Service: kong.Service{Name: kong.String("service-c")}},
+			},
+			Plugins: []file.FPlugin{
+				{Plugin: kong.Plugin{Name: kong.String("prometheus")}},
+				{Plugin: kong.Plugin{Name: kong.String("correlation-id")}},
+			},
+			Consumers: []file.FConsumer{
+				{
+					Consumer: kong.Consumer{Username: kong.String("consumer-a")},
+					KeyAuths: []*kong.KeyAuth{
+						{Key: kong.String("key-1")},
+						{Key: kong.String("key-2")},
+					},
+					ACLGroups: []*kong.ACLGroup{
+						{Group: kong.String("group-1")},
+						{Group: kong.String("group-2")},
+					},
+				},
+				{Consumer: kong.Consumer{Username: kong.String("consumer-b")}},
+			},
+			ConsumerGroups: []file.FConsumerGroupObject{
+				{ConsumerGroup: kong.ConsumerGroup{Name: kong.String("group-a")}},
+				{ConsumerGroup: kong.ConsumerGroup{Name: kong.String("group-b")}},
+			},
+			Upstreams: []file.FUpstream{
+				{
+					Upstream: kong.Upstream{Name: kong.String("upstream-a")},
+					Targets: []*file.FTarget{
+						{Target: kong.Target{Target: kong.String("10.0.0.1:80")}},
+						{Target: kong.Target{Target: kong.String("10.0.0.2:80")}},
+					},
+				},
+				{Upstream: kong.Upstream{Name: kong.String("upstream-b")}},
+			},
+			Certificates: []file.FCertificate{
+				{
+					Cert: kong.String("cert-a"),
+					SNIs: []kong.SNI{{Name: kong.String("a.example.com")}, {Name: kong.String("b.example.com")}},
+				},
+				{Cert: kong.String("cert-b")},
+			},
+			CACertificates: []file.FCACertificate{
+				{CACertificate: kong.CACertificate{Cert: kong.String("ca-cert-a")}},
+				{CACertificate: kong.CACertificate{Cert: kong.String("ca-cert-b")}},
+			},
+		}
+	}
+	shuffle := func(r *rand.Rand, c *file.Content) {
+		shuffleSlice(r, c.Services)
+		shuffleSlice(r, c.Services[0].Routes)
+		shuffleSlice(r, c.Services[0].Plugins)
+		shuffleSlice(r, c.Plugins)
+		shuffleSlice(r, c.Consumers)
+		for i := range c.Consumers {
+			shuffleSlice(r, c.Consumers[i].KeyAuths)
+			shuffleSlice(r, c.Consumers[i].ACLGroups)
+		}
+		shuffleSlice(r, c.ConsumerGroups)
+		shuffleSlice(r, c.Upstreams)
+		for i := range c.Upstreams {
+			shuffleSlice(r, c.Upstreams[i].Targets)
+		}
+		shuffleSlice(r, c.Certificates)
+		for i := range c.Certificates {
+			shuffleSlice(r, c.Certificates[i].SNIs)
+		}
+		shuffleSlice(r, c.CACertificates)
+	}
+
+	expected := newContent()
+	deckgen.NormalizeContentOrder(expected)
+	expectedSHA, err := deckgen.GenerateSHA(expected)
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(42)) //nolint:gosec
+	for i := 0; i < 20; i++ {
+		content := newContent()
+		shuffle(r, content)
+		deckgen.NormalizeContentOrder(content)
+
+		sha, err := deckgen.GenerateSHA(content)
+		require.NoError(t, err)
+		require.Equal(t, expectedSHA, sha, "SHA should not depend on the order of entities")
+	}
+}
+
+func TestNormalizeContentOrder_IsIdempotent(t *testing.T) {
+	content := &file.Content{
+		Services: []file.FService{
+			{Service: kong.Service{Name: kong.String("service-a")}},
+			{Service: kong.Service{Name: kong.String("service-c")}},
+			{Service: kong.Service{Name: kong.String("service-b")}},
+		},
+	}
+
+	deckgen.NormalizeContentOrder(content)
+	names := serviceNames(content)
+	require.Equal(t, []string{"service-c", "service-b", "service-a"}, names, "entities should be sorted as ToDeckContent sorts them")
+
+	deckgen.NormalizeContentOrder(content)
+	require.Equal(t, names, serviceNames(content))
+}
+
+func shuffleSlice[T any](r *rand.Rand, s []T) {
+	r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+}
+
+func serviceNames(content *file.Content) []string {
+	names := make([]string, 0, len(content.Services))
+	for _, s := range content.Services {
+		names = append(names, *s.Name)
+	}
+	return names
+}
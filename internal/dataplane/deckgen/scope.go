@@ -0,0 +1,115 @@
+package deckgen
+
+import (
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+)
+
+// ScopeContentByTags returns a copy of the content containing only entities that are tagged with all the given tags.
+// Nested entities (e.g. routes of a service) are kept only if they are tagged with all the given tags as well.
+// It's meant to be used for syncing a subset of entities (e.g. a single namespace's) with decK, with the current state
+// dump scoped using the same tags as selector tags. Entities that are out of scope are neither in the target nor in the
+// current state, so they're not considered deletions.
+func ScopeContentByTags(content *file.Content, tags []string) *file.Content {
+	scoped := *content
+	scoped.Services = filterScoped(content.Services, func(s file.FService) []*string { return s.Tags }, tags)
+	for i, s := range scoped.Services {
+		scoped.Services[i].Routes = scopeRoutes(s.Routes, tags)
+		scoped.Services[i].Plugins = scopePlugins(s.Plugins, tags)
+	}
+	scoped.Routes = lo.FilterMap(content.Routes, func(r file.FRoute, _ int) (file.FRoute, bool) {
+		if !hasAllTags(r.Tags, tags) {
+			return r, false
+		}
+		r.Plugins = scopePlugins(r.Plugins, tags)
+		return r, true
+	})
+	scoped.Consumers = lo.FilterMap(content.Consumers, func(c file.FConsumer, _ int) (file.FConsumer, bool) {
+		if !hasAllTags(c.Tags, tags) {
+			return c, false
+		}
+		c.Plugins = scopePlugins(c.Plugins, tags)
+		c.KeyAuths = filterScoped(c.KeyAuths, func(e *kong.KeyAuth) []*string { return e.Tags }, tags)
+		c.HMACAuths = filterScoped(c.HMACAuths, func(e *kong.HMACAuth) []*string { return e.Tags }, tags)
+		c.JWTAuths = filterScoped(c.JWTAuths, func(e *kong.JWTAuth) []*string { return e.Tags }, tags)
+		c.BasicAuths = filterScoped(c.BasicAuths, func(e *kong.BasicAuth) []*string { return e.Tags }, tags)
+		c.Oauth2Creds = filterScoped(c.Oauth2Creds, func(e *kong.Oauth2Credential) []*string { return e.Tags }, tags)
+		c.ACLGroups = filterScoped(c.ACLGroups, func(e *kong.ACLGroup) []*string { return e.Tags }, tags)
+		c.MTLSAuths = filterScoped(c.MTLSAuths, func(e *kong.MTLSAuth) []*string { return e.Tags }, tags)
+		return c, true
+	})
+	scoped.ConsumerGroups = filterScoped(content.ConsumerGroups, func(cg file.FConsumerGroupObject) []*string { return cg.Tags }, tags)
+
+	// Consumers and consumer groups reference each other by name. Drop references to entities that are out of scope.
+	scopedConsumers := lo.SliceToMap(scoped.Consumers, func(c file.FConsumer) (string, struct{}) {
+		return lo.FromPtr(c.Username), struct{}{}
+	})
+	scopedConsumerGroups := lo.SliceToMap(scoped.ConsumerGroups, func(cg file.FConsumerGroupObject) (string, struct{}) {
+		return lo.FromPtr(cg.Name), struct{}{}
+	})
+	for i, c := range scoped.Consumers {
+		scoped.Consumers[i].Groups = lo.Filter(c.Groups, func(g *kong.ConsumerGroup, _ int) bool {
+			_, ok := scopedConsumerGroups[lo.FromPtr(g.Name)]
+			return ok
+		})
+	}
+	for i, cg := range scoped.ConsumerGroups {
+		scoped.ConsumerGroups[i].Consumers = lo.Filter(cg.Consumers, func(c *kong.Consumer, _ int) bool {
+			_, ok := scopedConsumers[lo.FromPtr(c.Username)]
+			return ok
+		})
+	}
+
+	scoped.Plugins = filterScoped(content.Plugins, func(p file.FPlugin) []*string { return p.Tags }, tags)
+	scoped.Upstreams = lo.FilterMap(content.Upstreams, func(u file.FUpstream, _ int) (file.FUpstream, bool) {
+		if !hasAllTags(u.Tags, tags) {
+			return u, false
+		}
+		u.Targets = filterScoped(u.Targets, func(t *file.FTarget) []*string { return t.Tags }, tags)
+		return u, true
+	})
+	scoped.Certificates = lo.FilterMap(content.Certificates, func(c file.FCertificate, _ int) (file.FCertificate, bool) {
+		if !hasAllTags(c.Tags, tags) {
+			return c, false
+		}
+		c.SNIs = filterScoped(c.SNIs, func(sni kong.SNI) []*string { return sni.Tags }, tags)
+		return c, true
+	})
+	scoped.CACertificates = filterScoped(content.CACertificates, func(c file.FCACertificate) []*string { return c.Tags }, tags)
+	scoped.Vaults = filterScoped(content.Vaults, func(v file.FVault) []*string { return v.Tags }, tags)
+
+	return &scoped
+}
+
+func scopeRoutes(routes []*file.FRoute, tags []string) []*file.FRoute {
+	return lo.FilterMap(routes, func(r *file.FRoute, _ int) (*file.FRoute, bool) {
+		if !hasAllTags(r.Tags, tags) {
+			return r, false
+		}
+		scopedRoute := *r
+		scopedRoute.Plugins = scopePlugins(r.Plugins, tags)
+		return &scopedRoute, true
+	})
+}
+
+func scopePlugins(plugins []*file.FPlugin, tags []string) []*file.FPlugin {
+	return filterScoped(plugins, func(p *file.FPlugin) []*string { return p.Tags }, tags)
+}
+
+// filterScoped returns entities that are tagged with all the given tags.
+func filterScoped[T any](entities []T, entityTags func(T) []*string, tags []string) []T {
+	if entities == nil {
+		return nil
+	}
+	return lo.Filter(entities, func(e T, _ int) bool {
+		return hasAllTags(entityTags(e), tags)
+	})
+}
+
+func hasAllTags(entityTags []*string, tags []string) bool {
+	values := lo.FilterMap(entityTags, func(t *string, _ int) (string, bool) {
+		return lo.FromPtr(t), t != nil
+	})
+	return lo.Every(values, tags)
+}
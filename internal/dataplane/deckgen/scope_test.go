@@ -0,0 +1,116 @@
+package deckgen_test
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+)
+
+func TestScopeContentByTags(t *testing.T) {
+	var (
+		scope      = []string{"managed-by-ingress-controller", "k8s-namespace:ns-a"}
+		inScope    = kong.StringSlice("managed-by-ingress-controller", "k8s-namespace:ns-a", "k8s-name:a")
+		outOfScope = kong.StringSlice("managed-by-ingress-controller", "k8s-namespace:ns-b", "k8s-name:b")
+	)
+
+	content := &file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{
+				Service: kong.Service{Name: kong.String("service-a"), Tags: inScope},
+				Routes: []*file.FRoute{
+					{Route: kong.Route{Name: kong.String("route-a"), Tags: inScope}},
+					{Route: kong.Route{Name: kong.String("route-b"), Tags: outOfScope}},
+				},
+				Plugins: []*file.FPlugin{
+					{Plugin: kong.Plugin{Name: kong.String("plugin-b"), Tags: outOfScope}},
+				},
+			},
+			{
+				Service: kong.Service{Name: kong.String("service-b"), Tags: outOfScope},
+			},
+		},
+		Consumers: []file.FConsumer{
+			{
+				Consumer: kong.Consumer{Username: kong.String("consumer-a"), Tags: inScope},
+				KeyAuths: []*kong.KeyAuth{
+					{Key: kong.String("key-a"), Tags: inScope},
+					{Key: kong.String("key-b"), Tags: outOfScope},
+				},
+				Groups: []*kong.ConsumerGroup{
+					{Name: kong.String("group-a")},
+					{Name: kong.String("group-b")},
+				},
+			},
+			{
+				Consumer: kong.Consumer{Username: kong.String("consumer-b"), Tags: outOfScope},
+			},
+		},
+		ConsumerGroups: []file.FConsumerGroupObject{
+			{
+				ConsumerGroup: kong.ConsumerGroup{Name: kong.String("group-a"), Tags: inScope},
+				Consumers: []*kong.Consumer{
+					{Username: kong.String("consumer-a")},
+					{Username: kong.String("consumer-b")},
+				},
+			},
+			{
+				ConsumerGroup: kong.ConsumerGroup{Name: kong.String("group-b"), Tags: outOfScope},
+			},
+		},
+		Upstreams: []file.FUpstream{
+			{
+				Upstream: kong.Upstream{Name: kong.String("upstream-a"), Tags: inScope},
+				Targets: []*file.FTarget{
+					{Target: kong.Target{Target: kong.String("10.0.0.1:80"), Tags: inScope}},
+				},
+			},
+			{
+				Upstream: kong.Upstream{Name: kong.String("upstream-b"), Tags: outOfScope},
+			},
+		},
+		Plugins: []file.FPlugin{
+			{Plugin: kong.Plugin{Name: kong.String("plugin-a"), Tags: inScope}},
+			{Plugin: kong.Plugin{Name: kong.String("plugin-without-tags")}},
+		},
+	}
+
+	scoped := deckgen.ScopeContentByTags(content, scope)
+
+	require.Equal(t, "3.0", scoped.FormatVersion)
+
+	require.Len(t, scoped.Services, 1)
+	require.Equal(t, "service-a", *scoped.Services[0].Name)
+	require.Len(t, scoped.Services[0].Routes, 1)
+	require.Equal(t, "route-a", *scoped.Services[0].Routes[0].Name)
+	require.Empty(t, scoped.Services[0].Plugins)
+
+	require.Len(t, scoped.Consumers, 1)
+	require.Equal(t, "consumer-a", *scoped.Consumers[0].Username)
+	require.Len(t, scoped.Consumers[0].KeyAuths, 1)
+	require.Equal(t, "key-a", *scoped.Consumers[0].KeyAuths[0].Key)
+	require.Len(t, scoped.Consumers[0].Groups, 1, "references to out of scope consumer groups should be dropped")
+	require.Equal(t, "group-a", *scoped.Consumers[0].Groups[0].Name)
+
+	require.Len(t, scoped.ConsumerGroups, 1)
+	require.Equal(t, "group-a", *scoped.ConsumerGroups[0].Name)
+	require.Len(t, scoped.ConsumerGroups[0].Consumers, 1, "references to out of scope consumers should be dropped")
+	require.Equal(t, "consumer-a", *scoped.ConsumerGroups[0].Consumers[0].Username)
+
+	require.Len(t, scoped.Upstreams, 1)
+	require.Equal(t, "upstream-a", *scoped.Upstreams[0].Name)
+	require.Len(t, scoped.Upstreams[0].Targets, 1)
+
+	require.Len(t, scoped.Plugins, 1)
+	require.Equal(t, "plugin-a", *scoped.Plugins[0].Name)
+
+	// The original content should be left intact.
+	require.Len(t, content.Services, 2)
+	require.Len(t, content.Services[0].Routes, 2)
+	require.Len(t, content.Consumers[0].KeyAuths, 2)
+	require.Len(t, content.ConsumerGroups[0].Consumers, 2)
+}
@@ -0,0 +1,71 @@
+package deckgen
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kong/deck/file"
+	"github.com/samber/lo"
+)
+
+// ValidateUniqueIdentifiers verifies that entities in the content do not share identifiers that Kong requires to be
+// unique (e.g. names of services). Such duplicates would make Kong reject the configuration with a generic conflict
+// error, so they're reported upfront with the offending entities named.
+func ValidateUniqueIdentifiers(content *file.Content) error {
+	var (
+		serviceNames       []*string
+		routeNames         []*string
+		consumerUsernames  []*string
+		consumerCustomIDs  []*string
+		consumerGroupNames []*string
+		upstreamNames      []*string
+		vaultPrefixes      []*string
+	)
+	for _, s := range content.Services {
+		serviceNames = append(serviceNames, s.Name)
+		for _, r := range s.Routes {
+			routeNames = append(routeNames, r.Name)
+		}
+	}
+	for _, r := range content.Routes {
+		routeNames = append(routeNames, r.Name)
+	}
+	for _, c := range content.Consumers {
+		consumerUsernames = append(consumerUsernames, c.Username)
+		consumerCustomIDs = append(consumerCustomIDs, c.CustomID)
+	}
+	for _, cg := range content.ConsumerGroups {
+		consumerGroupNames = append(consumerGroupNames, cg.Name)
+	}
+	for _, u := range content.Upstreams {
+		upstreamNames = append(upstreamNames, u.Name)
+	}
+	for _, v := range content.Vaults {
+		vaultPrefixes = append(vaultPrefixes, v.Prefix)
+	}
+
+	return errors.Join(
+		duplicatesErr("service names", serviceNames),
+		duplicatesErr("route names", routeNames),
+		duplicatesErr("consumer usernames", consumerUsernames),
+		duplicatesErr("consumer custom IDs", consumerCustomIDs),
+		duplicatesErr("consumer group names", consumerGroupNames),
+		duplicatesErr("upstream names", upstreamNames),
+		duplicatesErr("vault prefixes", vaultPrefixes),
+	)
+}
+
+// duplicatesErr returns an error listing identifiers occurring more than once or nil if there are none.
+// Unset identifiers are ignored.
+func duplicatesErr(what string, identifiers []*string) error {
+	duplicates := lo.FindDuplicates(lo.FilterMap(identifiers, func(id *string, _ int) (string, bool) {
+		return lo.FromPtr(id), id != nil && *id != ""
+	}))
+	if len(duplicates) == 0 {
+		return nil
+	}
+	sort.Strings(duplicates)
+	return fmt.Errorf("duplicate %s: %s", what, strings.Join(duplicates, ", "))
+}
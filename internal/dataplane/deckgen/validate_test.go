@@ -0,0 +1,102 @@
+package deckgen_test
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+)
+
+func TestValidateUniqueIdentifiers(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       *file.Content
+		expectedError string
+	}{
+		{
+			name: "unique identifiers",
+			content: &file.Content{
+				Services: []file.FService{
+					{
+						Service: kong.Service{Name: kong.String("service-a")},
+						Routes: []*file.FRoute{
+							{Route: kong.Route{Name: kong.String("route-a")}},
+						},
+					},
+					{Service: kong.Service{Name: kong.String("service-b")}},
+				},
+				Consumers: []file.FConsumer{
+					{Consumer: kong.Consumer{Username: kong.String("consumer-a")}},
+					{Consumer: kong.Consumer{CustomID: kong.String("consumer-b")}},
+				},
+			},
+		},
+		{
+			name: "unset identifiers are not considered duplicates",
+			content: &file.Content{
+				Routes: []file.FRoute{
+					{Route: kong.Route{Paths: kong.StringSlice("/a")}},
+					{Route: kong.Route{Paths: kong.StringSlice("/b")}},
+				},
+			},
+		},
+		{
+			name: "duplicate service names",
+			content: &file.Content{
+				Services: []file.FService{
+					{Service: kong.Service{Name: kong.String("service-b")}},
+					{Service: kong.Service{Name: kong.String("service-a")}},
+					{Service: kong.Service{Name: kong.String("service-b")}},
+					{Service: kong.Service{Name: kong.String("service-a")}},
+				},
+			},
+			expectedError: "duplicate service names: service-a, service-b",
+		},
+		{
+			name: "duplicate route names across services and top-level routes",
+			content: &file.Content{
+				Services: []file.FService{
+					{
+						Service: kong.Service{Name: kong.String("service")},
+						Routes: []*file.FRoute{
+							{Route: kong.Route{Name: kong.String("route")}},
+						},
+					},
+				},
+				Routes: []file.FRoute{
+					{Route: kong.Route{Name: kong.String("route")}},
+				},
+			},
+			expectedError: "duplicate route names: route",
+		},
+		{
+			name: "multiple duplicates are all reported",
+			content: &file.Content{
+				Consumers: []file.FConsumer{
+					{Consumer: kong.Consumer{Username: kong.String("consumer"), CustomID: kong.String("id")}},
+					{Consumer: kong.Consumer{Username: kong.String("consumer"), CustomID: kong.String("id")}},
+				},
+				Upstreams: []file.FUpstream{
+					{Upstream: kong.Upstream{Name: kong.String("upstream")}},
+					{Upstream: kong.Upstream{Name: kong.String("upstream")}},
+				},
+			},
+			expectedError: "duplicate consumer usernames: consumer\nduplicate consumer custom IDs: id\nduplicate upstream names: upstream",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := deckgen.ValidateUniqueIdentifiers(tc.content)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/uuid"
 	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
 	"github.com/samber/lo"
@@ -27,6 +28,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/clients"
 	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/configfetcher"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
@@ -52,6 +54,24 @@ const (
 // Dataplane Client - Kong - Public Types
 // -----------------------------------------------------------------------------
 
+// GatewayQuorumNotReachedError is returned when the configuration was successfully applied to fewer gateways
+// than required by sendconfig.Config's GatewayQuorum. Errors holds the errors of the gateways that failed.
+type GatewayQuorumNotReachedError struct {
+	Succeeded int
+	Required  int
+	Total     int
+	Errors    []error
+}
+
+func (e GatewayQuorumNotReachedError) Error() string {
+	return fmt.Sprintf("configuration applied to %d out of %d gateways while %d are required: %s",
+		e.Succeeded, e.Total, e.Required, errors.Join(e.Errors...))
+}
+
+func (e GatewayQuorumNotReachedError) Unwrap() []error {
+	return e.Errors
+}
+
 // KongConfigBuilder builds a Kong configuration from a Kubernetes object cache.
 type KongConfigBuilder interface {
 	BuildKongConfig() translator.KongConfigBuildingResult
@@ -158,6 +178,15 @@ func NewKongClient(
 	parser KongConfigBuilder,
 	cacheStores store.CacheStores,
 ) (*KongClient, error) {
+	if kongConfig.SyncPause == nil {
+		kongConfig.SyncPause = sendconfig.NewSyncPause()
+	}
+	if kongConfig.PushCoalescer == nil {
+		kongConfig.PushCoalescer = sendconfig.NewPushCoalescer()
+	}
+	if kongConfig.SlowPushLogThrottler == nil {
+		kongConfig.SlowPushLogThrottler = sendconfig.NewDecayingLogThrottler(time.Minute, time.Hour)
+	}
 	c := &KongClient{
 		logger:                 logger,
 		requestTimeout:         timeout,
@@ -381,6 +410,11 @@ func (c *KongClient) Update(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	// Correlate all Admin API requests made during this update unless the caller has already done so.
+	if _, ok := adminapi.CorrelationIDFromContext(ctx); !ok {
+		ctx = adminapi.ContextWithCorrelationID(ctx, uuid.NewString())
+	}
+
 	// If Kong is running in dbless mode, we can fetch and store the last good configuration.
 	if c.dbmode.IsDBLessMode() {
 		// Fetch the last valid configuration from the proxy only in case there is no valid
@@ -408,23 +442,30 @@ func (c *KongClient) Update(ctx context.Context) error {
 		c.logger.V(util.DebugLevel).Info("Successfully built data-plane configuration")
 	}
 
-	shas, gatewaysSyncErr := c.sendOutToGatewayClients(ctx, parsingResult.KongState, c.kongConfig)
+	shas, pushed, gatewaysSyncErr := c.sendOutToGatewayClients(ctx, parsingResult.KongState, c.kongConfig)
 	konnectSyncErr := c.maybeSendOutToKonnectClient(ctx, parsingResult.KongState, c.kongConfig)
 
 	// Taking into account the results of syncing configuration with Gateways and Konnect, and potential translation
-	// failures, calculate the config status and update it.
+	// failures, calculate the config status and update it. Deliberately skipped pushes (e.g. due to syncing being
+	// paused) are not failures.
 	c.updateConfigStatus(ctx, clients.CalculateConfigStatus(
 		clients.CalculateConfigStatusInput{
-			GatewaysFailed:              gatewaysSyncErr != nil,
-			KonnectFailed:               konnectSyncErr != nil,
+			GatewaysFailed:              gatewaysSyncErr != nil && !sendconfig.IsUpdateSkippedError(gatewaysSyncErr),
+			KonnectFailed:               konnectSyncErr != nil && !sendconfig.IsUpdateSkippedError(konnectSyncErr),
 			TranslationFailuresOccurred: len(parsingResult.TranslationFailures) > 0,
 		},
 	))
 
 	// In case of a failure in syncing configuration with Gateways, propagate the error.
 	if gatewaysSyncErr != nil {
+		if sendconfig.IsUpdateSkippedError(gatewaysSyncErr) {
+			// Nothing has been pushed, so the configuration hasn't been rejected. Falling back to the last valid config
+			// makes no sense either as it would be skipped the same way (e.g. due to syncing being paused or the circuit
+			// breaker being open) or superseded by a newer configuration (when pushes are coalesced).
+			return gatewaysSyncErr
+		}
 		if state, found := c.kongConfigFetcher.LastValidConfig(); found {
-			_, fallbackSyncErr := c.sendOutToGatewayClients(ctx, state, c.kongConfig)
+			_, _, fallbackSyncErr := c.sendOutToGatewayClients(ctx, state, c.kongConfig)
 			if fallbackSyncErr != nil {
 				return errors.Join(gatewaysSyncErr, fallbackSyncErr)
 			}
@@ -435,9 +476,9 @@ func (c *KongClient) Update(ctx context.Context) error {
 
 	// report on configured Kubernetes objects if enabled
 	if c.AreKubernetesObjectReportsEnabled() {
-		// if the configuration SHAs that have just been pushed are different than
-		// what's been previously pushed.
-		if !slices.Equal(shas, c.SHAs) {
+		// if the configuration has actually been pushed and the configuration SHAs that have
+		// just been pushed are different than what's been previously pushed.
+		if pushed && !slices.Equal(shas, c.SHAs) {
 			c.logger.V(util.DebugLevel).Info("Triggering report for configured Kubernetes objects", "count",
 				len(parsingResult.ConfiguredKubernetesObjects))
 			c.triggerKubernetesObjectReport(parsingResult.ConfiguredKubernetesObjects, parsingResult.TranslationFailures)
@@ -449,10 +490,11 @@ func (c *KongClient) Update(ctx context.Context) error {
 }
 
 // sendOutToGatewayClients will generate deck content (config) from the provided kong state
-// and send it out to each of the configured gateway clients.
+// and send it out to each of the configured gateway clients. It reports whether the configuration
+// was pushed to any of them (i.e. it was not skipped for all of them).
 func (c *KongClient) sendOutToGatewayClients(
 	ctx context.Context, s *kongstate.KongState, config sendconfig.Config,
-) ([]string, error) {
+) ([]string, bool, error) {
 	gatewayClients := c.clientsProvider.GatewayClients()
 	if len(gatewayClients) == 0 {
 		c.logger.Error(
@@ -460,18 +502,53 @@ func (c *KongClient) sendOutToGatewayClients(
 			"Could not send configuration to gateways",
 		)
 		// Should not store the configuration in last valid config because the configuration is not validated on Kong gateway.
-		return c.SHAs, nil
+		return c.SHAs, false, nil
 	}
 
 	gatewayClientsToConfigure := c.clientsProvider.GatewayClientsToConfigure()
 	configureGatewayClientURLs := lo.Map(gatewayClientsToConfigure, func(cl *adminapi.Client, _ int) string { return cl.BaseRootURL() })
 	c.logger.V(util.DebugLevel).Info("Sending configuration to gateway clients", "urls", configureGatewayClientURLs)
 
-	shas, err := iter.MapErr(gatewayClientsToConfigure, func(client **adminapi.Client) (string, error) {
-		return c.sendToClient(ctx, *client, s, config)
+	type sendResult struct {
+		result sendconfig.UpdateResult
+		err    error
+	}
+	results := iter.Map(gatewayClientsToConfigure, func(client **adminapi.Client) sendResult {
+		result, err := c.sendToClient(ctx, *client, s, config)
+		return sendResult{result: result, err: err}
 	})
-	if err != nil {
-		return nil, err
+	var (
+		shas   []string
+		pushed bool
+		errs   []error
+	)
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		shas = append(shas, string(r.result.ConfigSHA))
+		pushed = pushed || r.result.Pushed
+	}
+	if len(errs) > 0 {
+		if config.GatewayQuorum <= 0 {
+			return nil, false, errors.Join(errs...)
+		}
+		quorumErr := GatewayQuorumNotReachedError{
+			Succeeded: len(shas),
+			Required:  min(config.GatewayQuorum, len(gatewayClientsToConfigure)),
+			Total:     len(gatewayClientsToConfigure),
+			Errors:    errs,
+		}
+		if quorumErr.Succeeded < quorumErr.Required {
+			return nil, false, quorumErr
+		}
+		c.logger.Error(quorumErr, "Configuration was not applied to some gateways, but the quorum was reached")
+	}
+
+	if config.DryRun {
+		// Nothing has been applied, hence neither the SHAs nor the last valid configuration change.
+		return c.SHAs, false, nil
 	}
 
 	// After a successful configuration update in DB mode,
@@ -491,7 +568,7 @@ func (c *KongClient) sendOutToGatewayClients(
 
 	c.kongConfigFetcher.StoreLastValidConfig(s)
 
-	return previousSHAs, nil
+	return previousSHAs, pushed, nil
 }
 
 // maybeSendOutToKonnectClient sends out the configuration to Konnect when KonnectClient is provided.
@@ -507,7 +584,7 @@ func (c *KongClient) maybeSendOutToKonnectClient(ctx context.Context, s *kongsta
 		// In case of an error, we only log it since we don't want the Konnect to affect the basic functionality
 		// of the controller.
 
-		if errors.As(err, &sendconfig.UpdateSkippedDueToBackoffStrategyError{}) {
+		if sendconfig.IsUpdateSkippedError(err) {
 			c.logger.Error(err, "Skipped pushing configuration to Konnect")
 		} else {
 			c.logger.Error(err, "Failed pushing configuration to Konnect")
@@ -523,7 +600,7 @@ func (c *KongClient) sendToClient(
 	client sendconfig.AdminAPIClient,
 	s *kongstate.KongState,
 	config sendconfig.Config,
-) (string, error) {
+) (sendconfig.UpdateResult, error) {
 	logger := c.logger.WithValues("url", client.AdminAPIClient().BaseRootURL())
 
 	deckGenParams := deckgen.GenerateDeckContentParams{
@@ -538,7 +615,7 @@ func (c *KongClient) sendToClient(
 	// apply the configuration update in Kong
 	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
 	defer cancel()
-	newConfigSHA, entityErrors, err := sendconfig.PerformUpdate(
+	updateResult, entityErrors, err := sendconfig.PerformUpdate(
 		timedCtx,
 		logger,
 		client,
@@ -548,6 +625,13 @@ func (c *KongClient) sendToClient(
 		c.updateStrategyResolver,
 		c.configChangeDetector,
 	)
+	logger = logger.WithValues("sync_id", updateResult.SyncID)
+
+	// Nothing was sent, hence there's nothing to report. Backoff skips are still reported as they've always been
+	// (they're the result of previously failed Konnect pushes).
+	if sendconfig.IsUpdateSkippedError(err) && !errors.As(err, &sendconfig.UpdateSkippedDueToBackoffStrategyError{}) {
+		return sendconfig.UpdateResult{}, err
+	}
 
 	c.recordResourceFailureEvents(entityErrors, KongConfigurationApplyFailedEventReason)
 	// Only record events on applying configuration to Kong gateway here.
@@ -556,17 +640,23 @@ func (c *KongClient) sendToClient(
 	}
 	sendDiagnostic(err != nil)
 
+	if errors.As(err, &deckerrors.ConfigPreconditionFailedError{}) {
+		// Kong's configuration was modified concurrently, hence the last applied SHA no longer describes it.
+		// Clearing it makes the next update push the configuration even if it hasn't changed.
+		logger.Error(err, "Kong's configuration has been modified concurrently, it will be overwritten on the next update")
+		client.SetLastConfigSHA(nil)
+	}
 	if err != nil {
 		if expired, ok := timedCtx.Deadline(); ok && time.Now().After(expired) {
 			logger.Error(nil, "Exceeded Kong API timeout, consider increasing --proxy-timeout-seconds")
 		}
-		return "", fmt.Errorf("performing update for %s failed: %w", client.AdminAPIClient().BaseRootURL(), err)
+		return sendconfig.UpdateResult{}, fmt.Errorf("performing update for %s failed: %w", client.AdminAPIClient().BaseRootURL(), err)
 	}
 
 	// update the lastConfigSHA with the new updated checksum
-	client.SetLastConfigSHA(newConfigSHA)
+	client.SetLastConfigSHA(updateResult.ConfigSHA)
 
-	return string(newConfigSHA), nil
+	return updateResult, nil
 }
 
 // SetConfigStatusNotifier sets a notifier which notifies subscribers about configuration sending results.
@@ -578,6 +668,41 @@ func (c *KongClient) SetConfigStatusNotifier(n clients.ConfigStatusNotifier) {
 	c.configStatusNotifier = n
 }
 
+// ResetConfigSHAs clears the SHAs of configurations last applied to the gateways and Konnect, so the next Update pushes the configuration even if it hasn't changed. It's meant to be used after
+// Kong's configuration was changed out-of-band (e.g. using the Admin API directly), which the controller may not detect.
+func (c *KongClient) ResetConfigSHAs() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, cl := range c.clientsProvider.GatewayClients() {
+		cl.SetLastConfigSHA(nil)
+	}
+	if konnectClient := c.clientsProvider.KonnectClient(); konnectClient != nil {
+		konnectClient.SetLastConfigSHA(nil)
+	}
+	c.SHAs = nil
+	c.logger.Info("Reset SHAs of the last applied configurations, next update will push the configuration")
+}
+
+// PauseSync pauses pushing configuration to the gateways and Konnect until ResumeSync is called (e.g. during
+// a maintenance), making Update fail with sendconfig.UpdateSkippedDueToSyncPauseError.
+func (c *KongClient) PauseSync() {
+	c.kongConfig.SyncPause.Pause()
+	c.logger.Info("Configuration sync paused")
+}
+
+// ResumeSync resumes pushing configuration paused with PauseSync. The next Update pushes the current configuration
+// even if it hasn't changed since the last push.
+func (c *KongClient) ResumeSync() {
+	c.kongConfig.SyncPause.Resume()
+	c.logger.Info("Configuration sync resumed")
+}
+
+// IsSyncPaused tells whether pushing configuration is paused with PauseSync.
+func (c *KongClient) IsSyncPaused() bool {
+	return c.kongConfig.SyncPause.Paused()
+}
+
 // -----------------------------------------------------------------------------
 // Dataplane Client - Kong - Private
 // -----------------------------------------------------------------------------
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"github.com/google/uuid"
@@ -31,6 +33,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/clients"
 	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/configfetcher"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
@@ -251,12 +254,13 @@ type mockUpdateStrategy struct {
 }
 
 func (m *mockUpdateStrategy) Update(_ context.Context, content sendconfig.ContentWithHash) (
+	stats sendconfig.UpdateStats,
 	err error,
 	resourceErrors []sendconfig.ResourceError,
 	resourceErrorsParseErr error,
 ) {
 	err = m.onUpdate(content)
-	return err, nil, nil
+	return stats, err, nil, nil
 }
 
 func (m *mockUpdateStrategy) MetricsProtocol() metrics.Protocol {
@@ -275,8 +279,11 @@ type mockConfigurationChangeDetector struct {
 
 func (m mockConfigurationChangeDetector) HasConfigurationChanged(
 	context.Context, []byte, []byte, *file.Content, sendconfig.KonnectAwareClient, sendconfig.StatusClient,
-) (bool, error) {
-	return m.hasConfigurationChanged, nil
+) (sendconfig.ConfigurationChange, error) {
+	if m.hasConfigurationChanged {
+		return sendconfig.ConfigurationChangedSHA, nil
+	}
+	return sendconfig.ConfigurationUnchanged, nil
 }
 
 func TestKongClientUpdate_AllExpectedClientsAreCalledAndErrorIsPropagated(t *testing.T) {
@@ -374,6 +381,137 @@ func TestKongClientUpdate_AllExpectedClientsAreCalledAndErrorIsPropagated(t *tes
 	}
 }
 
+func TestKongClient_ResetConfigSHAs(t *testing.T) {
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{
+			mustSampleGatewayClient(t),
+			mustSampleGatewayClient(t),
+		},
+		konnectClient: mustSampleKonnectClient(t),
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configChangeDetector := mockConfigurationChangeDetector{
+		hasConfigurationChanged: true,
+		status:                  defaultKongStatus,
+	}
+	kongClient := setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector,
+		newMockKongConfigBuilder(), nil, &mockKongLastValidConfigFetcher{})
+
+	require.NoError(t, kongClient.Update(context.Background()))
+	require.NotEmpty(t, kongClient.SHAs)
+	for _, cl := range clientsProvider.gatewayClients {
+		require.NotEmpty(t, cl.LastConfigSHA())
+	}
+	require.NotEmpty(t, clientsProvider.konnectClient.LastConfigSHA())
+
+	kongClient.ResetConfigSHAs()
+	require.Empty(t, kongClient.SHAs)
+	for _, cl := range clientsProvider.gatewayClients {
+		require.Empty(t, cl.LastConfigSHA())
+	}
+	require.Empty(t, clientsProvider.konnectClient.LastConfigSHA())
+}
+
+func TestKongClient_PauseSync(t *testing.T) {
+	gatewayClient := mustSampleGatewayClient(t)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{gatewayClient},
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	// Configuration is reported as unchanged, so it's pushed only when forced to.
+	configChangeDetector := mockConfigurationChangeDetector{
+		hasConfigurationChanged: false,
+		status:                  defaultKongStatus,
+	}
+	eventRecorder := mocks.NewEventRecorder()
+	kongClient := setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector,
+		newMockKongConfigBuilder(), eventRecorder, &mockKongLastValidConfigFetcher{})
+	statusQueue := newMockConfigStatusQueue()
+	kongClient.SetConfigStatusNotifier(statusQueue)
+
+	kongClient.PauseSync()
+	require.True(t, kongClient.IsSyncPaused())
+	require.ErrorAs(t, kongClient.Update(context.Background()), &sendconfig.UpdateSkippedDueToSyncPauseError{})
+	updateStrategyResolver.assertNoUpdateCalled()
+	// A deliberate pause is not a failure.
+	require.Equal(t, []clients.ConfigStatus{clients.ConfigStatusOK}, statusQueue.Notifications())
+	require.Empty(t, eventRecorder.Events())
+
+	kongClient.ResumeSync()
+	require.False(t, kongClient.IsSyncPaused())
+	require.NoError(t, kongClient.Update(context.Background()))
+	updateStrategyResolver.assertUpdateCalledForURLs([]string{gatewayClient.BaseRootURL()})
+
+	require.NoError(t, kongClient.Update(context.Background()))
+	updateStrategyResolver.assertUpdateCalledForURLs([]string{gatewayClient.BaseRootURL()})
+}
+
+func TestKongClientUpdate_GatewayQuorum(t *testing.T) {
+	testGatewayClients := []*adminapi.Client{
+		mustSampleGatewayClient(t),
+		mustSampleGatewayClient(t),
+		mustSampleGatewayClient(t),
+	}
+
+	testCases := []struct {
+		name                 string
+		quorum               int
+		errorOnUpdateForURLs []string
+		expectQuorumError    bool
+	}{
+		{
+			name:                 "quorum reached with one gateway failing",
+			quorum:               2,
+			errorOnUpdateForURLs: []string{testGatewayClients[0].BaseRootURL()},
+		},
+		{
+			name:   "quorum not reached with two gateways failing",
+			quorum: 2,
+			errorOnUpdateForURLs: []string{
+				testGatewayClients[0].BaseRootURL(),
+				testGatewayClients[1].BaseRootURL(),
+			},
+			expectQuorumError: true,
+		},
+		{
+			name:                 "quorum exceeding the number of gateways requires all of them",
+			quorum:               5,
+			errorOnUpdateForURLs: []string{testGatewayClients[0].BaseRootURL()},
+			expectQuorumError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			clientsProvider := mockGatewayClientsProvider{
+				gatewayClients: testGatewayClients,
+			}
+			updateStrategyResolver := newMockUpdateStrategyResolver(t)
+			for _, url := range tc.errorOnUpdateForURLs {
+				updateStrategyResolver.returnErrorOnUpdate(url, true)
+			}
+			configChangeDetector := mockConfigurationChangeDetector{hasConfigurationChanged: true}
+			kongClient := setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, newMockKongConfigBuilder(), nil, &mockKongLastValidConfigFetcher{})
+			kongClient.kongConfig.GatewayQuorum = tc.quorum
+
+			err := kongClient.Update(context.Background())
+			updateStrategyResolver.assertUpdateCalledForURLs(mapClientsToUrls(clientsProvider))
+			if !tc.expectQuorumError {
+				require.NoError(t, err)
+				return
+			}
+
+			var quorumErr GatewayQuorumNotReachedError
+			require.ErrorAs(t, err, &quorumErr)
+			require.Equal(t, len(testGatewayClients)-len(tc.errorOnUpdateForURLs), quorumErr.Succeeded)
+			require.Equal(t, min(tc.quorum, len(testGatewayClients)), quorumErr.Required)
+			require.Equal(t, len(testGatewayClients), quorumErr.Total)
+			require.Len(t, quorumErr.Errors, len(tc.errorOnUpdateForURLs))
+		})
+	}
+}
+
 func TestKongClientUpdate_WhenNoChangeInConfigNoClientGetsCalled(t *testing.T) {
 	clientsProvider := mockGatewayClientsProvider{
 		gatewayClients: []*adminapi.Client{
@@ -755,6 +893,94 @@ func (cf *mockKongLastValidConfigFetcher) TryFetchingValidConfigFromGateways(con
 	return nil
 }
 
+func TestKongClientUpdate_DryRunDoesNotStoreLastValidConfig(t *testing.T) {
+	// Kong with an empty configuration, serving only the read-only endpoints used to compute the dry run diff.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version": "3.4.0", "configuration": {"database": "off"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [], "next": null}`))
+	}))
+	t.Cleanup(server.Close)
+	gatewayClient, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{gatewayClient},
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configChangeDetector := mockConfigurationChangeDetector{hasConfigurationChanged: true}
+	lastValidConfigFetcher := &mockKongLastValidConfigFetcher{}
+	kongClient := setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector,
+		newMockKongConfigBuilder(), nil, lastValidConfigFetcher)
+	kongClient.kongConfig.DryRun = true
+	kongClient.kongConfig.Version = semver.MustParse("3.4.0")
+	kongClient.kongConfig.Concurrency = 1
+
+	require.NoError(t, kongClient.Update(context.Background()))
+	updateStrategyResolver.assertNoUpdateCalled()
+	_, found := lastValidConfigFetcher.LastValidConfig()
+	require.False(t, found, "configuration that hasn't been applied shouldn't be stored as the last valid one")
+	require.Empty(t, kongClient.SHAs)
+}
+
+func TestKongClientUpdate_ReadinessGateSkipIsNotAFailure(t *testing.T) {
+	gatewayClient := mustSampleGatewayClient(t)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{gatewayClient},
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configChangeDetector := mockConfigurationChangeDetector{hasConfigurationChanged: true}
+	// The last valid config is available, so a fallback push would be attempted if the skip was treated as a failure.
+	lastValidConfigFetcher := &mockKongLastValidConfigFetcher{lastKongState: &kongstate.KongState{}}
+	kongClient := setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector,
+		newMockKongConfigBuilder(), nil, lastValidConfigFetcher)
+	statusQueue := newMockConfigStatusQueue()
+	kongClient.SetConfigStatusNotifier(statusQueue)
+
+	gateChecks := 0
+	kongClient.kongConfig.ReadinessGate = func() bool {
+		gateChecks++
+		return false
+	}
+
+	err := kongClient.Update(context.Background())
+	require.ErrorAs(t, err, &sendconfig.UpdateSkippedDueToReadinessGateError{})
+	updateStrategyResolver.assertNoUpdateCalled()
+	require.Equal(t, 1, gateChecks, "the last valid config shouldn't be pushed as a fallback")
+	require.Equal(t, []clients.ConfigStatus{clients.ConfigStatusOK}, statusQueue.Notifications())
+}
+
+func TestKongClientUpdate_OpenCircuitSkipDoesNotPushLastValidConfig(t *testing.T) {
+	gatewayClient := mustSampleGatewayClient(t)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{gatewayClient},
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configChangeDetector := mockConfigurationChangeDetector{hasConfigurationChanged: true}
+	lastValidConfigFetcher := &mockKongLastValidConfigFetcher{lastKongState: &kongstate.KongState{}}
+	kongClient := setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector,
+		newMockKongConfigBuilder(), nil, lastValidConfigFetcher)
+
+	circuitBreaker := sendconfig.NewConflictCircuitBreaker(1, time.Hour)
+	circuitBreaker.Record(gatewayClient.BaseRootURL(), deckerrors.ConfigStatusError{
+		StatusCode: http.StatusConflict,
+		Err:        errors.New("conflict"),
+	})
+	kongClient.kongConfig.ConflictCircuitBreaker = circuitBreaker
+
+	err := kongClient.Update(context.Background())
+	require.ErrorAs(t, err, &sendconfig.UpdateSkippedDueToOpenCircuitError{})
+	updateStrategyResolver.assertNoUpdateCalled()
+	// A fallback push would be skipped as well, making the skip error joined with another one.
+	require.Equal(t, 1, strings.Count(err.Error(), "update skipped due to the circuit being open"),
+		"the last valid config shouldn't be pushed as a fallback")
+}
+
 func TestKongClientUpdate_FetchStoreAndPushLastValidConfig(t *testing.T) {
 	var (
 		ctx = context.Background()
@@ -48,15 +48,16 @@ func NewUpdateStrategyWithBackoff(
 // In case it is, apart from calling UpdateStrategy.Update, it will also register a success or a failure of an update
 // attempt so that the UpdateBackoffStrategy can keep track of it.
 func (s UpdateStrategyWithBackoff) Update(ctx context.Context, targetContent ContentWithHash) (
+	stats UpdateStats,
 	err error,
 	resourceErrors []ResourceError,
 	resourceErrorsParseErr error,
 ) {
 	if canUpdate, whyNot := s.backoffStrategy.CanUpdate(targetContent.Hash); !canUpdate {
-		return NewUpdateSkippedDueToBackoffStrategyError(whyNot), nil, nil
+		return UpdateStats{}, NewUpdateSkippedDueToBackoffStrategyError(whyNot), nil, nil
 	}
 
-	err, resourceErrors, resourceErrorsParseErr = s.decorated.Update(ctx, targetContent)
+	stats, err, resourceErrors, resourceErrorsParseErr = s.decorated.Update(ctx, targetContent)
 	if err != nil {
 		s.logger.V(util.DebugLevel).Info("Update failed, registering it for backoff strategy", "reason", err.Error())
 		s.backoffStrategy.RegisterUpdateFailure(err, targetContent.Hash)
@@ -64,7 +65,7 @@ func (s UpdateStrategyWithBackoff) Update(ctx context.Context, targetContent Con
 		s.backoffStrategy.RegisterUpdateSuccess()
 	}
 
-	return err, resourceErrors, resourceErrorsParseErr
+	return stats, err, resourceErrors, resourceErrorsParseErr
 }
 
 func (s UpdateStrategyWithBackoff) MetricsProtocol() metrics.Protocol {
@@ -25,6 +25,7 @@ func newMockUpdateStrategy(shouldSucceed bool) *mockUpdateStrategy {
 }
 
 func (m *mockUpdateStrategy) Update(context.Context, sendconfig.ContentWithHash) (
+	stats sendconfig.UpdateStats,
 	err error,
 	resourceErrors []sendconfig.ResourceError,
 	resourceErrorsParseErr error,
@@ -32,10 +33,10 @@ func (m *mockUpdateStrategy) Update(context.Context, sendconfig.ContentWithHash)
 	m.wasUpdateCalled = true
 
 	if !m.shouldSucceed {
-		return errors.New("update failure occurred"), nil, nil
+		return stats, errors.New("update failure occurred"), nil, nil
 	}
 
-	return nil, nil, nil
+	return stats, nil, nil, nil
 }
 
 func (m *mockUpdateStrategy) MetricsProtocol() metrics.Protocol {
@@ -119,7 +120,7 @@ func TestUpdateStrategyWithBackoff(t *testing.T) {
 			backoffStrategy := newMockBackoffStrategy(tc.updateShouldBeAllowed)
 
 			decoratedStrategy := sendconfig.NewUpdateStrategyWithBackoff(updateStrategy, backoffStrategy, logger)
-			err, _, _ := decoratedStrategy.Update(ctx, sendconfig.ContentWithHash{})
+			_, err, _, _ := decoratedStrategy.Update(ctx, sendconfig.ContentWithHash{})
 			if tc.expectError != nil {
 				require.Equal(t, tc.expectError, err)
 			} else {
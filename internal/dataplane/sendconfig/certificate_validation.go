@@ -0,0 +1,74 @@
+package sendconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+)
+
+// certificateExpiryWarningPeriod is how long before its expiry a certificate is reported as expiring soon.
+const certificateExpiryWarningPeriod = 7 * 24 * time.Hour
+
+// validateCertificates validates the content's certificates, returning errors associated with Kubernetes resources
+// (Secrets) the invalid certificates were generated from along with deckerrors.CertificateValidationError describing
+// all of them. A certificate is invalid when it or its private key can't be parsed, or when the key doesn't match
+// the certificate. Certificates that have expired or expire within certificateExpiryWarningPeriod are only warned
+// about as Kong accepts them.
+func validateCertificates(logger logr.Logger, content *file.Content, now time.Time) ([]ResourceError, error) {
+	var (
+		problems       []string
+		resourceErrors []ResourceError
+	)
+	for _, cert := range content.Certificates {
+		id := lo.FromPtr(cert.ID)
+		resourceError, resourceErr := parseRawResourceError(rawResourceError{
+			Name: id,
+			ID:   id,
+			Tags: lo.Map(cert.Tags, func(t *string, _ int) string { return lo.FromPtr(t) }),
+		})
+		source := fmt.Sprintf("certificate %s", id)
+		if resourceErr == nil {
+			source = fmt.Sprintf("certificate from %s %s/%s", resourceError.Kind, resourceError.Namespace, resourceError.Name)
+		}
+
+		leaf, err := parseCertificateKeyPair(lo.FromPtr(cert.Cert), lo.FromPtr(cert.Key))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", source, err))
+			if resourceErr != nil {
+				logger.Error(resourceErr, "Entity tags missing fields", "name", id)
+				continue
+			}
+			resourceError.Problems = map[string]string{"cert": err.Error()}
+			resourceErrors = append(resourceErrors, resourceError)
+			continue
+		}
+
+		if now.After(leaf.NotAfter) {
+			logger.Error(nil, "Certificate has expired", "certificate", source, "not_after", leaf.NotAfter)
+		} else if leaf.NotAfter.Sub(now) < certificateExpiryWarningPeriod {
+			logger.Error(nil, "Certificate expires soon", "certificate", source, "not_after", leaf.NotAfter)
+		}
+	}
+
+	if len(problems) > 0 {
+		return resourceErrors, deckerrors.CertificateValidationError{Problems: problems}
+	}
+	return nil, nil
+}
+
+// parseCertificateKeyPair parses a PEM-encoded certificate (chain) and its private key, verifying that they match.
+// It returns the leaf certificate.
+func parseCertificateKeyPair(cert, key string) (*x509.Certificate, error) {
+	keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(keyPair.Certificate[0])
+}
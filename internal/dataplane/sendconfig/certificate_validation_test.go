@@ -0,0 +1,87 @@
+package sendconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/zapr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/test/helpers/certificate"
+)
+
+func TestValidateCertificates(t *testing.T) {
+	secretTags := kong.StringSlice(
+		"k8s-name:tls", "k8s-namespace:default", "k8s-kind:Secret", "k8s-version:v1",
+		"k8s-uid:7b5a0d2c-3c0e-4c43-9a53-3d6b2c4f0f8e",
+	)
+	cert, key := certificate.MustGenerateSelfSignedCertPEMFormat()
+	_, otherKey := certificate.MustGenerateSelfSignedCertPEMFormat()
+	expiredCert, expiredKey := certificate.MustGenerateSelfSignedCertPEMFormat(certificate.WithAlreadyExpired())
+
+	t.Run("valid certificate", func(t *testing.T) {
+		core, logs := observer.New(zap.DebugLevel)
+		content := &file.Content{Certificates: []file.FCertificate{
+			{ID: kong.String("cert-1"), Cert: kong.String(string(cert)), Key: kong.String(string(key)), Tags: secretTags},
+		}}
+
+		resourceErrors, err := validateCertificates(zapr.NewLogger(zap.New(core)), content, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, resourceErrors)
+		require.Zero(t, logs.Len())
+	})
+
+	t.Run("key not matching the certificate", func(t *testing.T) {
+		content := &file.Content{Certificates: []file.FCertificate{
+			{ID: kong.String("cert-1"), Cert: kong.String(string(cert)), Key: kong.String(string(otherKey)), Tags: secretTags},
+		}}
+
+		resourceErrors, err := validateCertificates(zapr.NewLogger(zap.NewNop()), content, time.Now())
+		var validationErr deckerrors.CertificateValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.True(t, deckerrors.IsValidationErr(err))
+		require.Len(t, validationErr.Problems, 1)
+		require.Contains(t, validationErr.Problems[0], "certificate from Secret default/tls")
+		require.Contains(t, validationErr.Problems[0], "private key does not match public key")
+		require.Len(t, resourceErrors, 1)
+		require.Equal(t, "tls", resourceErrors[0].Name)
+		require.Equal(t, "default", resourceErrors[0].Namespace)
+		require.Equal(t, "Secret", resourceErrors[0].Kind)
+		require.Contains(t, resourceErrors[0].Problems["cert"], "private key does not match public key")
+	})
+
+	t.Run("malformed certificate without kubernetes metadata", func(t *testing.T) {
+		content := &file.Content{Certificates: []file.FCertificate{
+			{ID: kong.String("cert-1"), Cert: kong.String("not a certificate"), Key: kong.String(string(key))},
+		}}
+
+		resourceErrors, err := validateCertificates(zapr.NewLogger(zap.NewNop()), content, time.Now())
+		var validationErr deckerrors.CertificateValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Len(t, validationErr.Problems, 1)
+		require.Contains(t, validationErr.Problems[0], "certificate cert-1")
+		require.Empty(t, resourceErrors)
+	})
+
+	t.Run("expiring certificates are warned about", func(t *testing.T) {
+		core, logs := observer.New(zap.DebugLevel)
+		content := &file.Content{Certificates: []file.FCertificate{
+			{ID: kong.String("cert-1"), Cert: kong.String(string(cert)), Key: kong.String(string(key)), Tags: secretTags},
+			{ID: kong.String("cert-2"), Cert: kong.String(string(expiredCert)), Key: kong.String(string(expiredKey))},
+		}}
+
+		// Certificate cert-1 is valid for a year.
+		resourceErrors, err := validateCertificates(zapr.NewLogger(zap.New(core)), content, time.Now().AddDate(1, 0, -1))
+		require.NoError(t, err)
+		require.Empty(t, resourceErrors)
+		require.Equal(t, 1, logs.FilterMessage("Certificate expires soon").
+			FilterField(zap.String("certificate", "certificate from Secret default/tls")).Len())
+		require.Equal(t, 1, logs.FilterMessage("Certificate has expired").
+			FilterField(zap.String("certificate", "certificate cert-2")).Len())
+	})
+}
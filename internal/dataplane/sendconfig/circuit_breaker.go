@@ -0,0 +1,99 @@
+package sendconfig
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+)
+
+// UpdateSkippedDueToOpenCircuitError is returned from PerformUpdate when the configuration push was skipped due to
+// Config.ConflictCircuitBreaker being open for the data-plane.
+type UpdateSkippedDueToOpenCircuitError struct {
+	// Until is the time the circuit stays open until.
+	Until time.Time
+}
+
+func (e UpdateSkippedDueToOpenCircuitError) Error() string {
+	return fmt.Sprintf("update skipped due to the circuit being open after repeated conflicts until %s",
+		e.Until.Format(time.RFC3339),
+	)
+}
+
+// ConflictCircuitBreaker stops configuration pushes to a data-plane for a cooldown period after it rejected
+// a number of consecutive pushes due to conflicts (see deckerrors.IsConflictErr), so that a data-plane stuck
+// on a conflict isn't hammered with pushes that are bound to fail. Once the cooldown elapses, a single push is let
+// through: a success closes the circuit, another conflict opens it again right away.
+// A nil ConflictCircuitBreaker never stops pushes.
+type ConflictCircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	lock     sync.Mutex
+	circuits map[string]*circuit // Keyed by data-plane URL.
+}
+
+type circuit struct {
+	consecutiveConflicts int
+	tripped              bool
+	openUntil            time.Time
+}
+
+// NewConflictCircuitBreaker returns a ConflictCircuitBreaker opening for cooldown after threshold consecutive
+// conflicts. Threshold lower than 1 is treated as 1.
+func NewConflictCircuitBreaker(threshold int, cooldown time.Duration) *ConflictCircuitBreaker {
+	return &ConflictCircuitBreaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		now:       time.Now,
+		circuits:  map[string]*circuit{},
+	}
+}
+
+// Allow returns UpdateSkippedDueToOpenCircuitError if the circuit of the data-plane is open.
+func (b *ConflictCircuitBreaker) Allow(dataplane string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	c, ok := b.circuits[dataplane]
+	if !ok || !b.now().Before(c.openUntil) {
+		return nil
+	}
+	return UpdateSkippedDueToOpenCircuitError{Until: c.openUntil}
+}
+
+// Record records a result of a configuration push to the data-plane. A success closes its circuit, errors other than
+// conflicts are ignored.
+func (b *ConflictCircuitBreaker) Record(dataplane string, err error) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err == nil {
+		delete(b.circuits, dataplane)
+		return
+	}
+	if !deckerrors.IsConflictErr(err) {
+		return
+	}
+
+	c, ok := b.circuits[dataplane]
+	if !ok {
+		c = &circuit{}
+		b.circuits[dataplane] = c
+	}
+	c.consecutiveConflicts++
+	if c.tripped || c.consecutiveConflicts >= b.threshold {
+		c.tripped = true
+		c.openUntil = b.now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,57 @@
+package sendconfig
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflictCircuitBreaker(t *testing.T) {
+	const dataplane = "https://kong:8444"
+	conflictErr := kong.NewAPIError(http.StatusConflict, "conflict")
+
+	t.Run("nil never stops pushes", func(t *testing.T) {
+		var b *ConflictCircuitBreaker
+		b.Record(dataplane, conflictErr)
+		require.NoError(t, b.Allow(dataplane))
+	})
+
+	t.Run("opens after consecutive conflicts for the cooldown", func(t *testing.T) {
+		now := time.Now()
+		b := NewConflictCircuitBreaker(2, time.Minute)
+		b.now = func() time.Time { return now }
+
+		b.Record(dataplane, conflictErr)
+		require.NoError(t, b.Allow(dataplane))
+		b.Record(dataplane, errors.New("other error"))
+		require.NoError(t, b.Allow(dataplane), "errors other than conflicts should be ignored")
+		b.Record(dataplane, conflictErr)
+		err := b.Allow(dataplane)
+		require.ErrorAs(t, err, &UpdateSkippedDueToOpenCircuitError{})
+		require.NoError(t, b.Allow("https://other:8444"), "other data-planes should not be affected")
+
+		now = now.Add(time.Minute)
+		require.NoError(t, b.Allow(dataplane), "a push should be let through after the cooldown")
+		b.Record(dataplane, conflictErr)
+		require.Error(t, b.Allow(dataplane), "a single conflict after the cooldown should open the circuit again")
+	})
+
+	t.Run("success closes the circuit", func(t *testing.T) {
+		now := time.Now()
+		b := NewConflictCircuitBreaker(1, time.Minute)
+		b.now = func() time.Time { return now }
+
+		b.Record(dataplane, conflictErr)
+		require.Error(t, b.Allow(dataplane))
+		now = now.Add(time.Minute)
+		b.Record(dataplane, nil)
+		b.Record(dataplane, nil)
+		require.NoError(t, b.Allow(dataplane))
+		b.Record(dataplane, conflictErr)
+		require.Error(t, b.Allow(dataplane))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
 )
@@ -17,20 +18,41 @@ const (
 	WellKnownInitialHash = "00000000000000000000000000000000"
 )
 
+// ConfigurationChange tells whether and why configuration has to be pushed to a Kong instance.
+type ConfigurationChange int
+
+const (
+	// ConfigurationUnchanged means the configuration doesn't have to be pushed.
+	ConfigurationUnchanged ConfigurationChange = iota
+	// ConfigurationChangedSHA means the old and new config's SHAs differ.
+	ConfigurationChangedSHA
+	// ConfigurationChangedInitialHash means the SHAs are equal, but the Kong instance reported one of the initial
+	// configuration hashes, i.e. it has lost its configuration (e.g. it crashed or was restarted).
+	ConfigurationChangedInitialHash
+	// ConfigurationChangedStatusUnknown means the SHAs are equal, but the Kong instance's status couldn't be read
+	// and StatusErrorFallbackForcePush assumes it has lost its configuration.
+	ConfigurationChangedStatusUnknown
+)
+
+// Changed returns true if the configuration has to be pushed.
+func (c ConfigurationChange) Changed() bool {
+	return c != ConfigurationUnchanged
+}
+
 type ConfigurationChangeDetector interface {
 	// HasConfigurationChanged verifies whether configuration has changed by comparing
 	// old and new config's SHAs.
-	// In case the SHAs are equal, it still can return true if a client is considered
+	// In case the SHAs are equal, it still can report a change if a client is considered
 	// crashed or just booted up based on its status.
 	// In case the status indicates an empty config and the desired config is also empty
-	// this will return false to prevent continuously sending empty configuration to Gateway.
+	// this will report no change to prevent continuously sending empty configuration to Gateway.
 	HasConfigurationChanged(
 		ctx context.Context,
 		oldSHA, newSHA []byte,
 		targetConfig *file.Content,
 		client KonnectAwareClient,
 		statusClient StatusClient,
-	) (bool, error)
+	) (ConfigurationChange, error)
 }
 
 type KonnectAwareClient interface {
@@ -41,12 +63,53 @@ type StatusClient interface {
 	Status(context.Context) (*kong.Status, error)
 }
 
+// StatusErrorFallback defines what DefaultConfigurationChangeDetector assumes about a Kong instance when the
+// configuration SHAs are equal, but it fails to get the instance's status to check whether it has lost its
+// configuration (e.g. due to a restart).
+type StatusErrorFallback string
+
+const (
+	// StatusErrorFallbackSkip assumes the instance still has its configuration. The error is returned and
+	// the configuration is not pushed, which avoids needless (and, for large configurations, costly) reloads
+	// when the status endpoint is flaky, but delays re-pushing the configuration to an instance that has lost it
+	// until its status can be read again. It's the default.
+	StatusErrorFallbackSkip StatusErrorFallback = "skip"
+	// StatusErrorFallbackForcePush assumes the instance has lost its configuration and reports the configuration
+	// as changed, so it's pushed. It recovers instances that have lost their configuration as soon as possible,
+	// at the cost of reloading the configuration on every update while the status endpoint keeps failing.
+	StatusErrorFallbackForcePush StatusErrorFallback = "force-push"
+)
+
 type DefaultConfigurationChangeDetector struct {
-	logger logr.Logger
+	logger              logr.Logger
+	initialHashes       []string
+	statusErrorFallback StatusErrorFallback
 }
 
 func NewDefaultConfigurationChangeDetector(logger logr.Logger) *DefaultConfigurationChangeDetector {
-	return &DefaultConfigurationChangeDetector{logger: logger}
+	return &DefaultConfigurationChangeDetector{
+		logger:              logger,
+		initialHashes:       []string{WellKnownInitialHash},
+		statusErrorFallback: StatusErrorFallbackSkip,
+	}
+}
+
+// WithInitialHashes returns a copy of the detector that considers a Kong instance to have no configuration when
+// it reports any of the given configuration hashes (by default, it's only WellKnownInitialHash). It allows adjusting
+// the detection to Kong versions reporting their lack of configuration differently. With no hashes given,
+// Kong instances are never considered to have no configuration.
+func (d *DefaultConfigurationChangeDetector) WithInitialHashes(hashes ...string) *DefaultConfigurationChangeDetector {
+	detector := *d
+	detector.initialHashes = hashes
+	return &detector
+}
+
+// WithStatusErrorFallback returns a copy of the detector that falls back to the given assumption when it fails to
+// get a Kong instance's status. See StatusErrorFallback constants for the tradeoffs.
+func (d *DefaultConfigurationChangeDetector) WithStatusErrorFallback(fallback StatusErrorFallback) *DefaultConfigurationChangeDetector {
+	detector := *d
+	detector.statusErrorFallback = fallback
+	return &detector
 }
 
 func (d *DefaultConfigurationChangeDetector) HasConfigurationChanged(
@@ -55,48 +118,55 @@ func (d *DefaultConfigurationChangeDetector) HasConfigurationChanged(
 	targetConfig *file.Content,
 	client KonnectAwareClient,
 	statusClient StatusClient,
-) (bool, error) {
+) (ConfigurationChange, error) {
 	if !bytes.Equal(oldSHA, newSHA) {
-		return true, nil
+		return ConfigurationChangedSHA, nil
 	}
 
 	// In case of Konnect, we skip further steps that are meant to detect Kong instances crash/reset
 	// that are not relevant for Konnect.
 	// We're sure that if oldSHA and newSHA are equal, we are safe to skip the update.
 	if client.IsKonnect() {
-		return false, nil
+		return ConfigurationUnchanged, nil
 	}
 
 	// Check if a Kong instance has no configuration yet (could mean it crashed, was rebooted, etc.).
-	hasNoConfiguration, err := kongHasNoConfiguration(ctx, statusClient)
+	hasNoConfiguration, err := kongHasNoConfiguration(ctx, statusClient, d.initialHashes)
 	if err != nil {
-		return false, fmt.Errorf("failed to verify kong readiness: %w", err)
+		if d.statusErrorFallback == StatusErrorFallbackForcePush {
+			d.logger.Error(err, "Failed to verify Kong readiness, assuming it has no configuration and forcing configuration push")
+			if deckgen.IsContentEmpty(targetConfig) {
+				return ConfigurationUnchanged, nil
+			}
+			return ConfigurationChangedStatusUnknown, nil
+		}
+		return ConfigurationUnchanged, fmt.Errorf("failed to verify kong readiness: %w", err)
 	}
 
 	// Kong instance has no configuration, we should push despite the oldSHA and newSHA being equal...
 	if hasNoConfiguration {
 		// ... unless we're trying to push an empty config in such case skip.
 		if deckgen.IsContentEmpty(targetConfig) {
-			return false, nil
+			return ConfigurationUnchanged, nil
 		}
 
-		return true, nil
+		return ConfigurationChangedInitialHash, nil
 	}
 
-	return false, nil
+	return ConfigurationUnchanged, nil
 }
 
 // kongHasNoConfiguration checks Kong's status endpoint and read its config hash.
-// If the config hash reported by Kong is the known empty hash, it's considered crashed.
+// If the config hash reported by Kong is one of the known initial hashes, it's considered crashed.
 // This allows providing configuration to Kong instances that have unexpectedly crashed and
 // lost their configuration.
-func kongHasNoConfiguration(ctx context.Context, client StatusClient) (bool, error) {
+func kongHasNoConfiguration(ctx context.Context, client StatusClient, initialHashes []string) (bool, error) {
 	status, err := client.Status(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	if hasNoConfig := status.ConfigurationHash == WellKnownInitialHash; hasNoConfig {
+	if hasNoConfig := lo.Contains(initialHashes, status.ConfigurationHash); hasNoConfig {
 		return true, nil
 	}
 
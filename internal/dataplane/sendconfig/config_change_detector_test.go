@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
@@ -141,7 +142,118 @@ func TestDefaultConfigurationChangeDetector_HasConfigurationChanged(t *testing.T
 			}
 
 			require.NoError(t, err)
-			require.Equal(t, tc.expectedResult, result)
+			require.Equal(t, tc.expectedResult, result.Changed())
 		})
 	}
 }
+
+func TestDefaultConfigurationChangeDetector_WithInitialHashes(t *testing.T) {
+	const customInitialHash = "ffffffffffffffffffffffffffffffff"
+	var (
+		ctx     = context.Background()
+		sha     = []byte("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+		content = &file.Content{
+			FormatVersion: "3.0",
+			Services:      []file.FService{{Service: kong.Service{Name: kong.String("name")}}},
+		}
+		statusReporting = func(hash string) statusClientMock {
+			return statusClientMock{expectedValue: &kong.Status{ConfigurationHash: hash}}
+		}
+	)
+
+	testCases := []struct {
+		name           string
+		initialHashes  []string
+		statusHash     string
+		expectedResult bool
+	}{
+		{
+			name:           "custom initial hash is detected",
+			initialHashes:  []string{sendconfig.WellKnownInitialHash, customInitialHash},
+			statusHash:     customInitialHash,
+			expectedResult: true,
+		},
+		{
+			name:           "well-known initial hash is not detected when not configured",
+			initialHashes:  []string{customInitialHash},
+			statusHash:     sendconfig.WellKnownInitialHash,
+			expectedResult: false,
+		},
+		{
+			name:           "no initial hashes",
+			statusHash:     sendconfig.WellKnownInitialHash,
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			detector := sendconfig.NewDefaultConfigurationChangeDetector(logr.Discard()).WithInitialHashes(tc.initialHashes...)
+			result, err := detector.HasConfigurationChanged(ctx, sha, sha, content, konnectAwareClientMock{}, statusReporting(tc.statusHash))
+			require.NoError(t, err)
+			if tc.expectedResult {
+				require.Equal(t, sendconfig.ConfigurationChangedInitialHash, result)
+			} else {
+				require.Equal(t, sendconfig.ConfigurationUnchanged, result)
+			}
+		})
+	}
+}
+
+func TestDefaultConfigurationChangeDetector_StatusErrorFallback(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		sha          = []byte("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+		failedStatus = statusClientMock{expectedError: errors.New("status unavailable")}
+		content      = &file.Content{
+			FormatVersion: "3.0",
+			Services:      []file.FService{{Service: kong.Service{Name: kong.String("name")}}},
+		}
+		emptyContent = &file.Content{FormatVersion: "3.0"}
+	)
+
+	t.Run("skip by default", func(t *testing.T) {
+		detector := sendconfig.NewDefaultConfigurationChangeDetector(logr.Discard())
+		result, err := detector.HasConfigurationChanged(ctx, sha, sha, content, konnectAwareClientMock{}, failedStatus)
+		require.Error(t, err)
+		require.Equal(t, sendconfig.ConfigurationUnchanged, result)
+	})
+
+	t.Run("force push", func(t *testing.T) {
+		detector := sendconfig.NewDefaultConfigurationChangeDetector(logr.Discard()).
+			WithStatusErrorFallback(sendconfig.StatusErrorFallbackForcePush)
+		result, err := detector.HasConfigurationChanged(ctx, sha, sha, content, konnectAwareClientMock{}, failedStatus)
+		require.NoError(t, err)
+		require.Equal(t, sendconfig.ConfigurationChangedStatusUnknown, result, "initial hash wasn't observed")
+	})
+
+	t.Run("force push skips empty configuration", func(t *testing.T) {
+		detector := sendconfig.NewDefaultConfigurationChangeDetector(logr.Discard()).
+			WithStatusErrorFallback(sendconfig.StatusErrorFallbackForcePush)
+		result, err := detector.HasConfigurationChanged(ctx, sha, sha, emptyContent, konnectAwareClientMock{}, failedStatus)
+		require.NoError(t, err)
+		require.Equal(t, sendconfig.ConfigurationUnchanged, result)
+	})
+}
+
+// BenchmarkDefaultConfigurationChangeDetector_HasConfigurationChanged measures the SHAs comparison done on every
+// reconciliation in cases that do not require querying the Admin API status.
+func BenchmarkDefaultConfigurationChangeDetector_HasConfigurationChanged(b *testing.B) {
+	ctx := context.Background()
+	content := &file.Content{FormatVersion: "3.0"}
+	detector := sendconfig.NewDefaultConfigurationChangeDetector(logr.Discard())
+	sha := []byte("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+	otherSHA := []byte("82e35a63ceba37e9646434c5dd412ea577147f1e4a41ccde1614253187e3dbf9")
+
+	b.Run("different SHAs", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = detector.HasConfigurationChanged(ctx, sha, otherSHA, content, konnectAwareClientMock{}, statusClientMock{})
+		}
+	})
+	b.Run("equal SHAs in Konnect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = detector.HasConfigurationChanged(ctx, sha, sha, content, konnectAwareClientMock{expected: true}, statusClientMock{})
+		}
+	})
+}
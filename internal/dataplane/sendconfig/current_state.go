@@ -0,0 +1,134 @@
+package sendconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/deck/state"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// CurrentState returns the current configuration state of the data-plane the client communicates with.
+// It's fetched the same way (i.e. respecting the same filter tags, skipping CA certificates, etc.) as during
+// a configuration sync in DB mode, so it's what the controller believes the data-plane currently holds.
+// It's meant for debugging purposes, e.g. diagnosing drifts between the expected and the actual configuration.
+func CurrentState(ctx context.Context, client UpdateClient, config Config) (*state.KongState, error) {
+	return newUpdateStrategyDBModeForClient(client, config, logr.Discard()).CurrentState(ctx)
+}
+
+// CurrentStateJSON returns the current configuration state of the data-plane the client communicates with
+// (see CurrentState) serialized to JSON in decK's declarative configuration format.
+func CurrentStateJSON(ctx context.Context, client UpdateClient, config Config) ([]byte, error) {
+	return newUpdateStrategyDBModeForClient(client, config, logr.Discard()).CurrentStateJSON(ctx)
+}
+
+// CurrentStateTags returns distinct tags of entities in the current configuration state of the data-plane the client
+// communicates with (see CurrentState), e.g. to verify entities managed by the controller have the expected tags.
+// As the state is fetched the same way as during a sync, only entities having all the filter tags (see
+// Config.FilterTags) are taken into account when they're configured.
+func CurrentStateTags(ctx context.Context, client UpdateClient, config Config) ([]string, error) {
+	return newUpdateStrategyDBModeForClient(client, config, logr.Discard()).CurrentStateTags(ctx)
+}
+
+// CurrentState returns the current configuration state of the data-plane, fetched the same way as when syncing.
+func (s UpdateStrategyDBMode) CurrentState(ctx context.Context) (*state.KongState, error) {
+	cs, _, err := s.currentState(ctx)
+	return cs, err
+}
+
+// currentState returns the current configuration state of the data-plane along with the time spent waiting for
+// the strategy's DumpLimiter to allow dumping it.
+func (s UpdateStrategyDBMode) currentState(ctx context.Context) (*state.KongState, time.Duration, error) {
+	release, wait, err := s.dumpLimiter.acquire(ctx)
+	if err != nil {
+		return nil, wait, fmt.Errorf("failed waiting for dumping current state for %s: %w", s.client.BaseRootURL(), err)
+	}
+	defer release()
+
+	cs, err := s.stateDumper.Get(ctx, s.client, s.dumpConfig)
+	if err != nil {
+		return nil, wait, fmt.Errorf("failed getting current state for %s: %w", s.client.BaseRootURL(), err)
+	}
+	return cs, wait, nil
+}
+
+// CurrentStateJSON returns the current configuration state of the data-plane serialized to JSON
+// in decK's declarative configuration format.
+func (s UpdateStrategyDBMode) CurrentStateJSON(ctx context.Context) ([]byte, error) {
+	cs, err := s.CurrentState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := file.KongStateToContent(cs, file.WriteConfig{
+		SelectTags:  s.dumpConfig.SelectorTags,
+		WithID:      true,
+		KongVersion: s.version.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("converting current state to declarative configuration: %w", err)
+	}
+
+	b, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling current state: %w", err)
+	}
+	return b, nil
+}
+
+// CurrentStateTags returns distinct tags (sorted) of entities of all types in the current configuration state
+// of the data-plane.
+func (s UpdateStrategyDBMode) CurrentStateTags(ctx context.Context) ([]string, error) {
+	cs, err := s.CurrentState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Selector tags are not set in the write config as they'd be stripped from the entities.
+	content, err := file.KongStateToContent(cs, file.WriteConfig{
+		WithID:      true,
+		KongVersion: s.version.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("converting current state to declarative configuration: %w", err)
+	}
+	b, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling current state: %w", err)
+	}
+	var parsed any
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		return nil, fmt.Errorf("unmarshaling current state: %w", err)
+	}
+
+	tags := sets.New[string]()
+	collectEntityTags(parsed, tags)
+	return sets.List(tags), nil
+}
+
+// collectEntityTags recursively collects tags of entities (including nested ones, e.g. routes of services)
+// in the declarative configuration decoded from JSON.
+func collectEntityTags(v any, tags sets.Set[string]) {
+	switch v := v.(type) {
+	case map[string]any:
+		for field, value := range v {
+			if entityTags, ok := value.([]any); ok && field == "tags" {
+				for _, tag := range entityTags {
+					if tag, ok := tag.(string); ok {
+						tags.Insert(tag)
+					}
+				}
+				continue
+			}
+			collectEntityTags(value, tags)
+		}
+	case []any:
+		for _, value := range v {
+			collectEntityTags(value, tags)
+		}
+	}
+}
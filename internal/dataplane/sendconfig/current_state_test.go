@@ -0,0 +1,103 @@
+package sendconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/dump"
+	"github.com/kong/deck/file"
+	"github.com/kong/deck/state"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+func TestUpdateStrategyDBMode_CurrentStateJSON(t *testing.T) {
+	client, err := kong.NewTestClient(kong.String("http://localhost:8001"), nil)
+	require.NoError(t, err)
+	newStrategy := func(stateDumper sendconfig.StateDumper) sendconfig.UpdateStrategyDBMode {
+		return sendconfig.NewUpdateStrategyDBMode(
+			client, dump.Config{SelectorTags: []string{"managed-by-ingress-controller"}}, semver.MustParse("3.4.0"), 10, logr.Discard(),
+		).WithStateDumper(stateDumper)
+	}
+
+	t.Run("current state is returned as declarative configuration", func(t *testing.T) {
+		ks, err := state.NewKongState()
+		require.NoError(t, err)
+		require.NoError(t, ks.Services.Add(state.Service{Service: kong.Service{
+			ID:   kong.String("3ef5ec6a-5f1d-4ba0-a4f2-a0e54ed3c6bf"),
+			Name: kong.String("service"),
+			Host: kong.String("example.com"),
+			Tags: kong.StringSlice("managed-by-ingress-controller"),
+		}}))
+
+		b, err := newStrategy(fakeStateDumper{state: ks}).CurrentStateJSON(context.Background())
+		require.NoError(t, err)
+
+		var content file.Content
+		require.NoError(t, json.Unmarshal(b, &content))
+		require.Equal(t, "3.0", content.FormatVersion)
+		require.NotNil(t, content.Info)
+		require.Equal(t, []string{"managed-by-ingress-controller"}, content.Info.SelectorTags)
+		require.Len(t, content.Services, 1)
+		require.Equal(t, "3ef5ec6a-5f1d-4ba0-a4f2-a0e54ed3c6bf", *content.Services[0].ID)
+		require.Equal(t, "service", *content.Services[0].Name)
+	})
+
+	t.Run("dump failure is returned", func(t *testing.T) {
+		_, err := newStrategy(fakeStateDumper{err: errors.New("dump failed")}).CurrentStateJSON(context.Background())
+		require.ErrorContains(t, err, "dump failed")
+	})
+}
+
+func TestUpdateStrategyDBMode_CurrentStateTags(t *testing.T) {
+	client, err := kong.NewTestClient(kong.String("http://localhost:8001"), nil)
+	require.NoError(t, err)
+	newStrategy := func(stateDumper sendconfig.StateDumper) sendconfig.UpdateStrategyDBMode {
+		return sendconfig.NewUpdateStrategyDBMode(
+			client, dump.Config{SelectorTags: []string{"managed-by-ingress-controller"}}, semver.MustParse("3.4.0"), 10, logr.Discard(),
+		).WithStateDumper(stateDumper)
+	}
+
+	t.Run("distinct tags of entities of all types are returned", func(t *testing.T) {
+		ks, err := state.NewKongState()
+		require.NoError(t, err)
+		require.NoError(t, ks.Services.Add(state.Service{Service: kong.Service{
+			ID:   kong.String("3ef5ec6a-5f1d-4ba0-a4f2-a0e54ed3c6bf"),
+			Name: kong.String("service"),
+			Host: kong.String("example.com"),
+			Tags: kong.StringSlice("managed-by-ingress-controller", "k8s-name:service"),
+		}}))
+		require.NoError(t, ks.Routes.Add(state.Route{Route: kong.Route{
+			ID:      kong.String("0c0ff0a5-0ad3-4a3d-9e5c-0fc0ff0a5b7f"),
+			Name:    kong.String("route"),
+			Paths:   kong.StringSlice("/"),
+			Service: &kong.Service{ID: kong.String("3ef5ec6a-5f1d-4ba0-a4f2-a0e54ed3c6bf")},
+			Tags:    kong.StringSlice("managed-by-ingress-controller", "k8s-name:route"),
+		}}))
+		require.NoError(t, ks.Consumers.Add(state.Consumer{Consumer: kong.Consumer{
+			ID:       kong.String("5b1a6a4e-7c1e-4e3c-9c61-1b3a6a4e7c1e"),
+			Username: kong.String("consumer"),
+			Tags:     kong.StringSlice("created-by-another-tool"),
+		}}))
+
+		tags, err := newStrategy(fakeStateDumper{state: ks}).CurrentStateTags(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"created-by-another-tool",
+			"k8s-name:route",
+			"k8s-name:service",
+			"managed-by-ingress-controller",
+		}, tags)
+	})
+
+	t.Run("dump failure is returned", func(t *testing.T) {
+		_, err := newStrategy(fakeStateDumper{err: errors.New("dump failed")}).CurrentStateTags(context.Background())
+		require.ErrorContains(t, err, "dump failed")
+	})
+}
@@ -3,27 +3,61 @@ package sendconfig
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	gojson "github.com/goccy/go-json"
 	"github.com/kong/deck/diff"
 	"github.com/kong/deck/dump"
 	"github.com/kong/deck/file"
 	"github.com/kong/deck/state"
 	deckutils "github.com/kong/deck/utils"
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/mo"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 )
 
 // UpdateStrategyDBMode implements the UpdateStrategy interface. It updates Kong's data-plane
 // configuration using decK's syncer.
 type UpdateStrategyDBMode struct {
-	client      *kong.Client
-	dumpConfig  dump.Config
-	version     semver.Version
-	concurrency int
-	isKonnect   bool
+	client             *kong.Client
+	dumpConfig         dump.Config
+	version            semver.Version
+	concurrency        int
+	isKonnect          bool
+	logger             logr.Logger
+	stateDumper        StateDumper
+	entityTypes        []EntityType
+	ignoredEntityTypes []EntityType
+
+	autoConcurrency *AutoConcurrencyPolicy
+	maxConfigBytes  int
+	syncerOptions   DeckSyncerOptions
+	rollback        bool
+	dumpLimiter     *DumpLimiter
+	changeEvents    *ChangeEventStream
+}
+
+// StateDumper dumps the current configuration state of a Kong Admin API.
+type StateDumper interface {
+	Get(ctx context.Context, client *kong.Client, config dump.Config) (*state.KongState, error)
+}
+
+// DeckStateDumper is the default StateDumper implementation using decK's dump.
+type DeckStateDumper struct{}
+
+func (DeckStateDumper) Get(ctx context.Context, client *kong.Client, config dump.Config) (*state.KongState, error) {
+	rawState, err := dump.Get(ctx, client, config)
+	if err != nil {
+		return nil, fmt.Errorf("loading configuration from kong: %w", err)
+	}
+
+	return state.Get(rawState)
 }
 
 func NewUpdateStrategyDBMode(
@@ -31,12 +65,15 @@ func NewUpdateStrategyDBMode(
 	dumpConfig dump.Config,
 	version semver.Version,
 	concurrency int,
+	logger logr.Logger,
 ) UpdateStrategyDBMode {
 	return UpdateStrategyDBMode{
 		client:      client,
 		dumpConfig:  dumpConfig,
 		version:     version,
 		concurrency: concurrency,
+		logger:      logger,
+		stateDumper: DeckStateDumper{},
 	}
 }
 
@@ -45,44 +82,161 @@ func NewUpdateStrategyDBModeKonnect(
 	dumpConfig dump.Config,
 	version semver.Version,
 	concurrency int,
+	logger logr.Logger,
 ) UpdateStrategyDBMode {
-	s := NewUpdateStrategyDBMode(client, dumpConfig, version, concurrency)
+	s := NewUpdateStrategyDBMode(client, dumpConfig, version, concurrency, logger)
 	s.isKonnect = true
 	return s
 }
 
+// WithStateDumper returns a copy of the strategy using the given StateDumper to get the current state.
+func (s UpdateStrategyDBMode) WithStateDumper(stateDumper StateDumper) UpdateStrategyDBMode {
+	s.stateDumper = stateDumper
+	return s
+}
+
+// WithEntityTypes returns a copy of the strategy that syncs only entities of the given types, leaving entities
+// of other types untouched. When no types are given, entities of all types are synced.
+func (s UpdateStrategyDBMode) WithEntityTypes(entityTypes []EntityType) UpdateStrategyDBMode {
+	s.entityTypes = entityTypes
+	return s
+}
+
+// WithIgnoredEntityTypes returns a copy of the strategy that leaves entities of the given types untouched: they're
+// excluded from both the current and the target state, hence they're neither created, updated nor deleted, even when
+// they match the selector tags. It's meant for entities managed by other means than the controller (e.g. vaults).
+func (s UpdateStrategyDBMode) WithIgnoredEntityTypes(entityTypes []EntityType) UpdateStrategyDBMode {
+	s.ignoredEntityTypes = entityTypes
+	return s
+}
+
+// WithAutoConcurrency returns a copy of the strategy with its concurrency derived from the number of entities in
+// the target configuration using the given policy instead of being static.
+func (s UpdateStrategyDBMode) WithAutoConcurrency(policy AutoConcurrencyPolicy) UpdateStrategyDBMode {
+	s.autoConcurrency = &policy
+	return s
+}
+
+// WithMaxConfigBytes returns a copy of the strategy that refuses to sync configuration whose estimated size
+// exceeds maxConfigBytes, failing with deckerrors.ConfigTooLargeError before the current state is dumped
+// and any changes are made. Configuration that can't be serialized for the check is refused as well.
+// Zero means no limit.
+func (s UpdateStrategyDBMode) WithMaxConfigBytes(maxConfigBytes int) UpdateStrategyDBMode {
+	s.maxConfigBytes = maxConfigBytes
+	return s
+}
+
+// WithSyncerOptions returns a copy of the strategy that creates decK's syncer with the given options.
+func (s UpdateStrategyDBMode) WithSyncerOptions(opts DeckSyncerOptions) UpdateStrategyDBMode {
+	s.syncerOptions = opts
+	return s
+}
+
+// WithDumpLimiter returns a copy of the strategy that dumps the current state only when allowed by the limiter.
+func (s UpdateStrategyDBMode) WithDumpLimiter(limiter *DumpLimiter) UpdateStrategyDBMode {
+	s.dumpLimiter = limiter
+	return s
+}
+
+// WithChangeEvents returns a copy of the strategy that publishes a ChangeEvent to the stream for every entity change
+// applied successfully (changes are not published when they're rolled back, see WithRollback).
+func (s UpdateStrategyDBMode) WithChangeEvents(stream *ChangeEventStream) UpdateStrategyDBMode {
+	s.changeEvents = stream
+	return s
+}
+
+// concurrencyFor returns the concurrency to use for syncing the target content.
+func (s UpdateStrategyDBMode) concurrencyFor(targetContent *file.Content) int {
+	if s.autoConcurrency != nil {
+		return s.autoConcurrency.Concurrency(targetContent)
+	}
+	return s.concurrency
+}
+
 func (s UpdateStrategyDBMode) Update(ctx context.Context, targetContent ContentWithHash) (
+	stats UpdateStats,
 	err error,
 	resourceErrors []ResourceError,
 	resourceErrorsParseErr error,
 ) {
-	cs, err := s.currentState(ctx)
+	if err := deckgen.ValidateUniqueIdentifiers(targetContent.Content); err != nil {
+		return stats, deckerrors.ConfigConflictError{Err: err}, nil, nil
+	}
+
+	// Target content is not sent to the Admin API as a whole in DB mode, but its serialized size
+	// is a good approximation of the configuration size that's being synced. It's checked against
+	// the limit before the current state is dumped, which is costly for large configurations.
+	serialized, err := gojson.Marshal(targetContent.Content)
 	if err != nil {
-		return fmt.Errorf("failed getting current state for %s: %w", s.client.BaseRootURL(), err), nil, nil
+		if s.maxConfigBytes > 0 {
+			return stats, fmt.Errorf("failed to serialize configuration to check its size: %w", err), nil, nil
+		}
+		s.logger.Error(err, "Failed to serialize configuration, its size won't be reported")
+	} else {
+		stats.PayloadSize = mo.Some(len(serialized))
+		if s.maxConfigBytes > 0 && len(serialized) > s.maxConfigBytes {
+			return stats, deckerrors.ConfigTooLargeError{Size: len(serialized), Limit: s.maxConfigBytes}, nil, nil
+		}
 	}
 
-	ts, err := s.targetState(ctx, cs, targetContent.Content)
+	timer := newEntityTypeTimer()
+	var recorder *changeRecorder
+	if s.changeEvents != nil {
+		recorder = &changeRecorder{}
+	}
+	syncer, targetState, timings, err := s.newSyncer(ctx, targetContent.Content, timer, recorder)
+	stats.PreparationDuration = timings.targetState
+	if s.dumpLimiter != nil {
+		stats.DumpWaitDuration = mo.Some(timings.dumpWait)
+	}
 	if err != nil {
-		return deckerrors.ConfigConflictError{Err: err}, nil, nil
+		return stats, err, nil, nil
+	}
+
+	var snapshot *state.KongState
+	if s.rollback {
+		if snapshot, err = s.snapshot(ctx); err != nil {
+			s.logger.Error(err, "Failed to take a snapshot of the current state, configuration won't be rolled back on failure")
+		}
+	}
+
+	solveStats, errs, _ := syncer.Solve(ctx, s.concurrencyFor(targetContent.Content), false, false)
+	stats.EntityTypeDurations = timer.Durations()
+	stats.QueueWaitDurations = timer.QueueWaits()
+	stats.Diff = mo.Some(diffSummaryFromStats(solveStats))
+	if errs != nil {
+		// Some of the changes may have been applied, hence only resources of the failed entities are reported.
+		failures := parseEntityFailures(errs)
+		rolledBack := snapshot != nil && s.rollBack(ctx, snapshot)
+		if !rolledBack {
+			recorder.publishApplied(s.changeEvents, s.client.BaseRootURL(), failures)
+		}
+		return stats, PartialUpdateError{
+			Applied:    diffSummaryFromStats(solveStats),
+			Failed:     failures,
+			RolledBack: rolledBack,
+			Err:        deckutils.ErrArray{Errors: errs},
+		}, entityFailuresToResourceErrors(failures, targetState, s.logger), nil
 	}
 
-	syncer, err := diff.NewSyncer(diff.SyncerOpts{
-		CurrentState:    cs,
-		TargetState:     ts,
-		KongClient:      s.client,
-		SilenceWarnings: true,
-		IsKonnect:       s.isKonnect,
-	})
+	recorder.publishApplied(s.changeEvents, s.client.BaseRootURL(), nil)
+	return stats, nil, nil, nil
+}
+
+// Diff computes changes that would be made to the data-plane's configuration if targetContent was applied,
+// without applying them.
+func (s UpdateStrategyDBMode) Diff(ctx context.Context, targetContent *file.Content) (DiffSummary, error) {
+	syncer, _, _, err := s.newSyncer(ctx, targetContent, nil, nil)
 	if err != nil {
-		return fmt.Errorf("creating a new syncer for %s: %w", s.client.BaseRootURL(), err), nil, nil
+		return DiffSummary{}, err
 	}
 
-	_, errs, _ := syncer.Solve(ctx, s.concurrency, false, false)
+	stats, errs, _ := syncer.Solve(ctx, s.concurrencyFor(targetContent), true, false)
 	if errs != nil {
-		return deckutils.ErrArray{Errors: errs}, nil, nil
+		return DiffSummary{}, deckutils.ErrArray{Errors: errs}
 	}
 
-	return nil, nil, nil
+	return diffSummaryFromStats(stats), nil
 }
 
 func (s UpdateStrategyDBMode) MetricsProtocol() metrics.Protocol {
@@ -93,13 +247,92 @@ func (s UpdateStrategyDBMode) Type() string {
 	return "DBMode"
 }
 
-func (s UpdateStrategyDBMode) currentState(ctx context.Context) (*state.KongState, error) {
-	rawState, err := dump.Get(ctx, s.client, s.dumpConfig)
+// syncerTimings are durations of newSyncer's steps.
+type syncerTimings struct {
+	// dumpWait is the time spent waiting for the DumpLimiter to allow dumping the current state.
+	dumpWait time.Duration
+	// targetState is the time spent on building the target state.
+	targetState time.Duration
+}
+
+// newSyncer creates a decK syncer for the current and target states. It also returns the target state and
+// the durations of its steps. When timer and recorder are set, they're notified about every entity change.
+func (s UpdateStrategyDBMode) newSyncer(
+	ctx context.Context,
+	targetContent *file.Content,
+	timer *entityTypeTimer,
+	recorder *changeRecorder,
+) (*diff.Syncer, *state.KongState, syncerTimings, error) {
+	var timings syncerTimings
+	cs, dumpWait, err := s.currentState(ctx)
+	timings.dumpWait = dumpWait
 	if err != nil {
-		return nil, fmt.Errorf("loading configuration from kong: %w", err)
+		return nil, nil, timings, err
 	}
 
-	return state.Get(rawState)
+	targetStateStart := time.Now()
+	ts, err := s.targetState(ctx, cs, targetContent)
+	timings.targetState = time.Since(targetStateStart)
+	if err != nil {
+		return nil, nil, timings, deckerrors.ConfigConflictError{Err: err}
+	}
+
+	// Target state is built using the whole current state so that IDs of all entities are resolved.
+	if cs, err = s.scopeState(cs); err != nil {
+		return nil, nil, timings, err
+	}
+	if ts, err = s.scopeState(ts); err != nil {
+		return nil, nil, timings, err
+	}
+
+	onEntityChange := func(a ...any) {
+		timer.observeEntityChange(a...)
+		recorder.observeEntityChange(a...)
+		s.logEntityChange(a...)
+	}
+	syncer, err := diff.NewSyncer(s.syncerOptions.apply(diff.SyncerOpts{
+		CurrentState:  cs,
+		TargetState:   ts,
+		KongClient:    s.client,
+		IsKonnect:     s.isKonnect,
+		CreatePrintln: onEntityChange,
+		UpdatePrintln: onEntityChange,
+		DeletePrintln: onEntityChange,
+	}))
+	if err != nil {
+		return nil, nil, timings, fmt.Errorf("creating a new syncer for %s: %w", s.client.BaseRootURL(), err)
+	}
+
+	return syncer, ts, timings, nil
+}
+
+// scopeState restricts the state to entities of types synced by the strategy (see WithEntityTypes and
+// WithIgnoredEntityTypes). The state may be modified in place.
+func (s UpdateStrategyDBMode) scopeState(ks *state.KongState) (*state.KongState, error) {
+	if len(s.entityTypes) > 0 {
+		var err error
+		if ks, err = restrictStateToEntityTypes(ks, s.entityTypes); err != nil {
+			return nil, err
+		}
+	}
+	if len(s.ignoredEntityTypes) > 0 {
+		if err := removeEntityTypesFromState(ks, s.ignoredEntityTypes); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+// logEntityChange is used as decK's syncer printing function that is called for every entity change.
+// It's called with the operation, entity kind, entity name and (for updates only) a diff of the entity.
+// The diff is not logged as it may contain sensitive data.
+func (s UpdateStrategyDBMode) logEntityChange(a ...any) {
+	if len(a) < 3 {
+		return
+	}
+	s.logger.V(util.DebugLevel).Info("Entity change",
+		"operation", a[0], "kind", a[1], "name", a[2],
+	)
 }
 
 func (s UpdateStrategyDBMode) targetState(
@@ -0,0 +1,111 @@
+package sendconfig
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ChangeEvent describes a change of a single entity applied to a data-plane in DB mode.
+type ChangeEvent struct {
+	// Dataplane is the URL of the data-plane the change was applied to.
+	Dataplane string
+	// Operation is the applied operation ("create", "update" or "delete").
+	Operation string
+	// Kind is the kind of the entity as named by decK (e.g. "service" or "route").
+	Kind string
+	// Name identifies the entity.
+	Name string
+}
+
+// ChangeEventStream streams ChangeEvents of entity changes applied by UpdateStrategyDBMode to an external consumer
+// (e.g. an audit system) reading them from Events. Events are sent without blocking: when the consumer doesn't keep
+// up and the channel's buffer is full, events are dropped and counted (see Dropped), so that the consumer never
+// stalls configuration pushes. A nil ChangeEventStream discards all events.
+type ChangeEventStream struct {
+	events  chan ChangeEvent
+	dropped atomic.Uint64
+}
+
+// NewChangeEventStream returns a ChangeEventStream buffering up to bufferSize events.
+func NewChangeEventStream(bufferSize int) *ChangeEventStream {
+	return &ChangeEventStream{
+		events: make(chan ChangeEvent, bufferSize),
+	}
+}
+
+// Events returns the channel the events are sent to.
+func (s *ChangeEventStream) Events() <-chan ChangeEvent {
+	return s.events
+}
+
+// Dropped returns the number of events dropped due to the channel's buffer being full.
+func (s *ChangeEventStream) Dropped() uint64 {
+	if s == nil {
+		return 0
+	}
+	return s.dropped.Load()
+}
+
+func (s *ChangeEventStream) publish(event ChangeEvent) {
+	if s == nil {
+		return
+	}
+	select {
+	case s.events <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// syncerOperations maps operations as reported by decK's syncer printing functions to the ones of ChangeEvent.
+var syncerOperations = map[string]string{
+	"creating": "create",
+	"updating": "update",
+	"deleting": "delete",
+}
+
+// changeRecorder records entity changes reported by decK's syncer printing functions, so that changes applied
+// successfully can be published once the sync is done (the functions are called before a change is sent to Kong).
+// A nil changeRecorder records nothing.
+type changeRecorder struct {
+	lock    sync.Mutex
+	changes []ChangeEvent
+}
+
+// observeEntityChange is meant to be called for every entity change with decK's syncer printing function arguments
+// (the operation, entity kind, entity name, ...).
+func (r *changeRecorder) observeEntityChange(a ...any) {
+	if r == nil || len(a) < 3 {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.changes = append(r.changes, ChangeEvent{
+		Operation: syncerOperations[fmt.Sprint(a[0])],
+		Kind:      fmt.Sprint(a[1]),
+		Name:      fmt.Sprint(a[2]),
+	})
+}
+
+// publishApplied publishes recorded changes of the data-plane, except the failed ones, to the stream.
+func (r *changeRecorder) publishApplied(stream *ChangeEventStream, dataplane string, failures []EntityFailure) {
+	if r == nil {
+		return
+	}
+	failed := make(map[ChangeEvent]struct{}, len(failures))
+	for _, f := range failures {
+		failed[ChangeEvent{Operation: f.Operation, Kind: f.Kind, Name: f.Name}] = struct{}{}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, change := range r.changes {
+		if _, ok := failed[change]; ok {
+			continue
+		}
+		change.Dataplane = dataplane
+		stream.publish(change)
+	}
+}
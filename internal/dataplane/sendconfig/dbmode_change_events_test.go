@@ -0,0 +1,80 @@
+package sendconfig_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/dump"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+func TestUpdateStrategyDBMode_ChangeEvents(t *testing.T) {
+	newStrategy := func(t *testing.T, stream *sendconfig.ChangeEventStream) (sendconfig.UpdateStrategyDBMode, string) {
+		server := httptest.NewServer(failingRouteHandler{
+			Handler:   newFakeAdminAPIHandler(t, 0),
+			routeName: "service-1-route",
+		})
+		t.Cleanup(server.Close)
+		client, err := kong.NewClient(kong.String(server.URL), server.Client())
+		require.NoError(t, err)
+		strategy := sendconfig.NewUpdateStrategyDBMode(
+			client, dump.Config{}, semver.MustParse("3.4.0"), 10, logr.Discard(),
+		).WithChangeEvents(stream)
+		return strategy, client.BaseRootURL()
+	}
+	drain := func(stream *sendconfig.ChangeEventStream) []sendconfig.ChangeEvent {
+		var events []sendconfig.ChangeEvent
+		for {
+			select {
+			case event := <-stream.Events():
+				events = append(events, event)
+			default:
+				return events
+			}
+		}
+	}
+
+	t.Run("applied changes are published", func(t *testing.T) {
+		stream := sendconfig.NewChangeEventStream(10)
+		strategy, dataplane := newStrategy(t, stream)
+
+		_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: largeContent(1)})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []sendconfig.ChangeEvent{
+			{Dataplane: dataplane, Operation: "create", Kind: "service", Name: "service-0"},
+			{Dataplane: dataplane, Operation: "create", Kind: "route", Name: "service-0-route"},
+		}, drain(stream))
+
+		content := largeContent(2)
+		content.Services[0].Host = kong.String("service-0.changed.svc")
+		_, err, _, _ = strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: content})
+		require.ErrorAs(t, err, &sendconfig.PartialUpdateError{})
+		require.ElementsMatch(t, []sendconfig.ChangeEvent{
+			{Dataplane: dataplane, Operation: "update", Kind: "service", Name: "service-0"},
+			{Dataplane: dataplane, Operation: "create", Kind: "service", Name: "service-1"},
+		}, drain(stream), "the failed change shouldn't be published")
+		require.Zero(t, stream.Dropped())
+	})
+
+	t.Run("events are dropped when the stream is full", func(t *testing.T) {
+		stream := sendconfig.NewChangeEventStream(1)
+		strategy, _ := newStrategy(t, stream)
+
+		_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: largeContent(1)})
+		require.NoError(t, err)
+		require.Len(t, drain(stream), 1)
+		require.Equal(t, uint64(1), stream.Dropped())
+	})
+
+	t.Run("nil stream discards events", func(t *testing.T) {
+		strategy, _ := newStrategy(t, nil)
+		_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: largeContent(1)})
+		require.NoError(t, err)
+	})
+}
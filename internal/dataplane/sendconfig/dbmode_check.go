@@ -0,0 +1,83 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kong/go-kong/kong"
+
+	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
+)
+
+// DBModeMismatchError is returned when a Kong instance runs in a different DB mode than the controller is configured
+// to push configuration in (e.g. the controller pushes configuration via `POST /config` that is available in DB-less
+// mode only, but Kong is running with a database).
+type DBModeMismatchError struct {
+	URL          string
+	InMemory     bool
+	ActualDBMode dpconf.DBMode
+}
+
+func (e DBModeMismatchError) Error() string {
+	expected := "DB-less"
+	if !e.InMemory {
+		expected = "DB-backed"
+	}
+	return fmt.Sprintf("Kong at %s runs with database %q while the controller is configured for %s mode, "+
+		"make sure all Kong instances use the same database setting", e.URL, e.ActualDBMode, expected)
+}
+
+// DBModeChecker verifies that Kong instances run in the DB mode the controller pushes configuration in. Every Kong
+// instance is probed once and the result is cached, so it doesn't cost an additional request on every push.
+type DBModeChecker struct {
+	lock    sync.Mutex
+	dbModes map[string]dpconf.DBMode // Keyed by Admin API base URL.
+}
+
+func NewDBModeChecker() *DBModeChecker {
+	return &DBModeChecker{
+		dbModes: make(map[string]dpconf.DBMode),
+	}
+}
+
+// Check returns DBModeMismatchError if the Kong instance the client communicates with doesn't run in the expected
+// DB mode. Failing to probe the instance results in a different error and the instance is probed again next time.
+func (c *DBModeChecker) Check(ctx context.Context, client *kong.Client, inMemory bool) error {
+	url := client.BaseRootURL()
+
+	c.lock.Lock()
+	dbMode, ok := c.dbModes[url]
+	c.lock.Unlock()
+	if !ok {
+		var err error
+		if dbMode, err = probeDBMode(ctx, client); err != nil {
+			return err
+		}
+		c.lock.Lock()
+		c.dbModes[url] = dbMode
+		c.lock.Unlock()
+	}
+
+	if dbMode.IsDBLessMode() != inMemory {
+		return DBModeMismatchError{URL: url, InMemory: inMemory, ActualDBMode: dbMode}
+	}
+	return nil
+}
+
+// probeDBMode returns the DB mode reported by the Kong instance in its root configuration.
+func probeDBMode(ctx context.Context, client *kong.Client) (dpconf.DBMode, error) {
+	root, err := client.Root(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed fetching Kong root: %w", err)
+	}
+	configuration, ok := root["configuration"].(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("malformed configuration found in Kong root: %v", root["configuration"])
+	}
+	database, ok := configuration["database"].(string)
+	if !ok {
+		return "", fmt.Errorf("malformed database found in Kong root configuration: %v", configuration["database"])
+	}
+	return dpconf.NewDBMode(database)
+}
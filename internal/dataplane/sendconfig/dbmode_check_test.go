@@ -0,0 +1,86 @@
+package sendconfig_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+// newKongRootServer returns a server serving Kong's root endpoint with the given database setting. It counts
+// requests to the root endpoint.
+func newKongRootServer(t *testing.T, database string) (*httptest.Server, *atomic.Int32) {
+	var rootRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rootRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"version": "3.4.1", "configuration": {"database": %q}}`, database)
+	}))
+	t.Cleanup(server.Close)
+	return server, &rootRequests
+}
+
+func TestDBModeChecker(t *testing.T) {
+	testCases := []struct {
+		name           string
+		database       string
+		inMemory       bool
+		expectMismatch bool
+	}{
+		{name: "DB-less Kong with in-memory config", database: "off", inMemory: true},
+		{name: "DB-backed Kong with DB mode config", database: "postgres", inMemory: false},
+		{name: "DB-backed Kong with in-memory config", database: "postgres", inMemory: true, expectMismatch: true},
+		{name: "DB-less Kong with DB mode config", database: "off", inMemory: false, expectMismatch: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server, rootRequests := newKongRootServer(t, tc.database)
+			client, err := adminapi.NewTestClient(server.URL)
+			require.NoError(t, err)
+
+			checker := sendconfig.NewDBModeChecker()
+			for i := 0; i < 3; i++ {
+				err := checker.Check(context.Background(), client.AdminAPIClient(), tc.inMemory)
+				if !tc.expectMismatch {
+					require.NoError(t, err)
+					continue
+				}
+				var mismatchErr sendconfig.DBModeMismatchError
+				require.ErrorAs(t, err, &mismatchErr)
+				require.Equal(t, dpconf.DBMode(tc.database), mismatchErr.ActualDBMode)
+				require.Equal(t, tc.inMemory, mismatchErr.InMemory)
+			}
+			require.Equal(t, int32(1), rootRequests.Load(), "Kong should be probed only once")
+		})
+	}
+}
+
+func TestPerformUpdate_DBModeChecker(t *testing.T) {
+	server, _ := newKongRootServer(t, "postgres")
+	client, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+
+	strategy := &diffReportingUpdateStrategy{}
+	_, _, err = sendconfig.PerformUpdate(context.Background(), logr.Discard(), client,
+		sendconfig.Config{InMemory: true, DBModeChecker: sendconfig.NewDBModeChecker()}, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.ErrorAs(t, err, &sendconfig.DBModeMismatchError{})
+	require.False(t, strategy.wasCalled, "configuration should not be pushed to Kong running in a different DB mode")
+}
@@ -0,0 +1,37 @@
+package sendconfig
+
+import (
+	"github.com/kong/deck/file"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+)
+
+// DefaultAutoConcurrencyEntitiesPerWorker is the default number of entities per concurrent sync worker
+// used by AutoConcurrencyPolicy.
+const DefaultAutoConcurrencyEntitiesPerWorker = 100
+
+// AutoConcurrencyPolicy derives the concurrency of DB mode syncs from the number of entities in the target
+// configuration: one worker per EntitiesPerWorker entities, bounded by Min and Max.
+type AutoConcurrencyPolicy struct {
+	// Min is the minimal concurrency. Values lower than 1 are treated as 1.
+	Min int
+	// Max is the maximal concurrency. Values lower than Min are treated as Min.
+	Max int
+	// EntitiesPerWorker is the number of entities per worker. DefaultAutoConcurrencyEntitiesPerWorker is used when
+	// it's not positive.
+	EntitiesPerWorker int
+}
+
+// Concurrency returns the concurrency for syncing the content.
+func (p AutoConcurrencyPolicy) Concurrency(content *file.Content) int {
+	minConcurrency := max(p.Min, 1)
+	maxConcurrency := max(p.Max, minConcurrency)
+	entitiesPerWorker := p.EntitiesPerWorker
+	if entitiesPerWorker <= 0 {
+		entitiesPerWorker = DefaultAutoConcurrencyEntitiesPerWorker
+	}
+
+	entities := deckgen.CountEntities(content)
+	workers := (entities + entitiesPerWorker - 1) / entitiesPerWorker
+	return min(max(workers, minConcurrency), maxConcurrency)
+}
@@ -0,0 +1,56 @@
+package sendconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+func TestAutoConcurrencyPolicy_Concurrency(t *testing.T) {
+	testCases := []struct {
+		name                string
+		policy              sendconfig.AutoConcurrencyPolicy
+		servicesCount       int
+		expectedConcurrency int
+	}{
+		{
+			name:                "small config gets min concurrency",
+			policy:              sendconfig.AutoConcurrencyPolicy{Min: 2, Max: 20, EntitiesPerWorker: 100},
+			servicesCount:       10,
+			expectedConcurrency: 2,
+		},
+		{
+			name:                "concurrency grows with entities count",
+			policy:              sendconfig.AutoConcurrencyPolicy{Min: 2, Max: 20, EntitiesPerWorker: 100},
+			servicesCount:       250, // 500 entities: services and their routes.
+			expectedConcurrency: 5,
+		},
+		{
+			name:                "huge config gets max concurrency",
+			policy:              sendconfig.AutoConcurrencyPolicy{Min: 2, Max: 20, EntitiesPerWorker: 100},
+			servicesCount:       5000,
+			expectedConcurrency: 20,
+		},
+		{
+			name:                "defaults are used for invalid bounds and entities per worker",
+			policy:              sendconfig.AutoConcurrencyPolicy{Min: 0, Max: -1},
+			servicesCount:       500,
+			expectedConcurrency: 1,
+		},
+		{
+			name:                "default entities per worker",
+			policy:              sendconfig.AutoConcurrencyPolicy{Min: 1, Max: 100},
+			servicesCount:       500,
+			expectedConcurrency: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedConcurrency, tc.policy.Concurrency(largeContent(tc.servicesCount)))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package sendconfig
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// entityTypeTimer approximates time spent by decK's syncer on syncing entities of every type. The syncer doesn't
+// expose per-type timings, but it processes entity types one at a time, waiting for all operations on entities
+// of a type to complete before moving on to the next one. Time between the first operations of subsequent types
+// is therefore attributed to the former one. A nil entityTypeTimer records nothing.
+//
+// It also approximates how long every entity change waited for a free syncer worker. All changes of a phase
+// (deletions of a type, or creations and updates of a type) are queued for workers when the phase starts, and
+// a change is reported just before a worker sends it to Kong, hence the time between the phase's first change
+// and the change being reported is the time it waited in the queue. Long waits mean the sync is bottlenecked
+// on the number of workers rather than on Kong.
+type entityTypeTimer struct {
+	lock         sync.Mutex
+	current      string
+	currentStart time.Time
+	durations    map[string]time.Duration
+
+	phase      string
+	phaseStart time.Time
+	queueWaits []time.Duration
+}
+
+func newEntityTypeTimer() *entityTypeTimer {
+	return &entityTypeTimer{
+		durations: map[string]time.Duration{},
+	}
+}
+
+// observeEntityChange is meant to be called for every entity change with decK's syncer printing function arguments
+// (the operation, entity kind, entity name, ...).
+func (t *entityTypeTimer) observeEntityChange(a ...any) {
+	if t == nil || len(a) < 2 {
+		return
+	}
+	kind := fmt.Sprint(a[1])
+	phase := kind
+	if a[0] == "deleting" {
+		phase = "deleting " + kind
+	}
+	now := time.Now()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if phase != t.phase {
+		t.phase = phase
+		t.phaseStart = now
+	}
+	t.queueWaits = append(t.queueWaits, now.Sub(t.phaseStart))
+
+	if kind == t.current {
+		return
+	}
+	t.finishCurrentLocked(now)
+	t.current = kind
+	t.currentStart = now
+}
+
+// Durations returns durations attributed to entity types (keyed by decK's entity kind) once the sync is done.
+func (t *entityTypeTimer) Durations() map[string]time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.finishCurrentLocked(time.Now())
+	t.current = ""
+	return t.durations
+}
+
+// QueueWaits returns approximate times entity changes waited for a free syncer worker once the sync is done.
+func (t *entityTypeTimer) QueueWaits() []time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.queueWaits
+}
+
+func (t *entityTypeTimer) finishCurrentLocked(now time.Time) {
+	if t.current != "" {
+		t.durations[t.current] += now.Sub(t.currentStart)
+	}
+}
@@ -0,0 +1,34 @@
+package sendconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityTypeTimer(t *testing.T) {
+	timer := newEntityTypeTimer()
+
+	timer.observeEntityChange("creating", "service", "service-1")
+	timer.observeEntityChange("creating", "service", "service-2")
+	time.Sleep(10 * time.Millisecond)
+	timer.observeEntityChange("updating", "route", "route-1", "diff")
+	time.Sleep(10 * time.Millisecond)
+	timer.observeEntityChange("deleting", "service", "service-3")
+	timer.observeEntityChange("malformed")
+
+	durations := timer.Durations()
+	require.Len(t, durations, 2)
+	require.GreaterOrEqual(t, durations["service"], 10*time.Millisecond)
+	require.GreaterOrEqual(t, durations["route"], 10*time.Millisecond)
+
+	queueWaits := timer.QueueWaits()
+	require.Len(t, queueWaits, 4)
+	require.Zero(t, queueWaits[0], "first change of a phase doesn't wait")
+	require.Zero(t, queueWaits[2])
+	require.Zero(t, queueWaits[3], "deletions are a separate phase")
+
+	var nilTimer *entityTypeTimer
+	require.NotPanics(t, func() { nilTimer.observeEntityChange("creating", "service", "service-1") })
+}
@@ -0,0 +1,163 @@
+package sendconfig
+
+import (
+	"fmt"
+
+	"github.com/kong/deck/state"
+	"github.com/samber/lo"
+)
+
+// EntityType is a type of Kong entities that a DB mode configuration sync can be restricted to (or that can be
+// excluded from it).
+type EntityType string
+
+const (
+	// EntityTypeCertificates covers certificates and their SNIs.
+	EntityTypeCertificates EntityType = "certificates"
+	// EntityTypeCACertificates covers CA certificates.
+	EntityTypeCACertificates EntityType = "ca_certificates"
+	// EntityTypeConsumers covers consumers and their credentials.
+	EntityTypeConsumers EntityType = "consumers"
+	// EntityTypeVaults covers vaults.
+	EntityTypeVaults EntityType = "vaults"
+)
+
+// restrictStateToEntityTypes returns a new state containing only entities of the given types from the given state.
+func restrictStateToEntityTypes(ks *state.KongState, entityTypes []EntityType) (*state.KongState, error) {
+	restricted, err := state.NewKongState()
+	if err != nil {
+		return nil, fmt.Errorf("creating a new state: %w", err)
+	}
+
+	for _, entityType := range entityTypes {
+		var err error
+		switch entityType {
+		case EntityTypeCertificates:
+			err = copyEntities(ks.Certificates.GetAll, restricted.Certificates.Add)
+			if err == nil {
+				err = copyEntities(ks.SNIs.GetAll, restricted.SNIs.Add)
+			}
+		case EntityTypeCACertificates:
+			err = copyEntities(ks.CACertificates.GetAll, restricted.CACertificates.Add)
+		case EntityTypeConsumers:
+			err = copyConsumers(ks, restricted)
+		case EntityTypeVaults:
+			err = copyEntities(ks.Vaults.GetAll, restricted.Vaults.Add)
+		default:
+			err = fmt.Errorf("unsupported entity type %q", entityType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("restricting state to %s: %w", entityType, err)
+		}
+	}
+
+	return restricted, nil
+}
+
+// removeEntityTypesFromState removes entities of the given types from the given state.
+func removeEntityTypesFromState(ks *state.KongState, entityTypes []EntityType) error {
+	for _, entityType := range entityTypes {
+		var err error
+		switch entityType {
+		case EntityTypeCertificates:
+			// SNIs are removed first as they refer to certificates.
+			err = deleteEntities(ks.SNIs.GetAll, func(e *state.SNI) *string { return e.ID }, ks.SNIs.Delete)
+			if err == nil {
+				err = deleteEntities(ks.Certificates.GetAll, func(e *state.Certificate) *string { return e.ID }, ks.Certificates.Delete)
+			}
+		case EntityTypeCACertificates:
+			err = deleteEntities(ks.CACertificates.GetAll, func(e *state.CACertificate) *string { return e.ID }, ks.CACertificates.Delete)
+		case EntityTypeConsumers:
+			err = deleteConsumers(ks)
+		case EntityTypeVaults:
+			err = deleteEntities(ks.Vaults.GetAll, func(e *state.Vault) *string { return e.ID }, ks.Vaults.Delete)
+		default:
+			err = fmt.Errorf("unsupported entity type %q", entityType)
+		}
+		if err != nil {
+			return fmt.Errorf("removing %s from state: %w", entityType, err)
+		}
+	}
+	return nil
+}
+
+func copyConsumers(from, to *state.KongState) error {
+	for _, copyFn := range []func() error{
+		func() error { return copyEntities(from.Consumers.GetAll, to.Consumers.Add) },
+		func() error { return copyEntities(from.KeyAuths.GetAll, to.KeyAuths.Add) },
+		func() error { return copyEntities(from.HMACAuths.GetAll, to.HMACAuths.Add) },
+		func() error { return copyEntities(from.JWTAuths.GetAll, to.JWTAuths.Add) },
+		func() error { return copyEntities(from.BasicAuths.GetAll, to.BasicAuths.Add) },
+		func() error { return copyEntities(from.ACLGroups.GetAll, to.ACLGroups.Add) },
+		func() error { return copyEntities(from.Oauth2Creds.GetAll, to.Oauth2Creds.Add) },
+		func() error { return copyEntities(from.MTLSAuths.GetAll, to.MTLSAuths.Add) },
+	} {
+		if err := copyFn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyEntities adds all entities returned by getAll using add.
+func copyEntities[T any](getAll func() ([]*T, error), add func(T) error) error {
+	entities, err := getAll()
+	if err != nil {
+		return err
+	}
+	for _, e := range entities {
+		if err := add(*e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteConsumers removes consumers along with their credentials (removed first as they refer to consumers).
+func deleteConsumers(ks *state.KongState) error {
+	for _, deleteFn := range []func() error{
+		func() error {
+			return deleteEntities(ks.KeyAuths.GetAll, func(e *state.KeyAuth) *string { return e.ID }, ks.KeyAuths.Delete)
+		},
+		func() error {
+			return deleteEntities(ks.HMACAuths.GetAll, func(e *state.HMACAuth) *string { return e.ID }, ks.HMACAuths.Delete)
+		},
+		func() error {
+			return deleteEntities(ks.JWTAuths.GetAll, func(e *state.JWTAuth) *string { return e.ID }, ks.JWTAuths.Delete)
+		},
+		func() error {
+			return deleteEntities(ks.BasicAuths.GetAll, func(e *state.BasicAuth) *string { return e.ID }, ks.BasicAuths.Delete)
+		},
+		func() error {
+			return deleteEntities(ks.ACLGroups.GetAll, func(e *state.ACLGroup) *string { return e.ID }, ks.ACLGroups.Delete)
+		},
+		func() error {
+			return deleteEntities(ks.Oauth2Creds.GetAll, func(e *state.Oauth2Credential) *string { return e.ID }, ks.Oauth2Creds.Delete)
+		},
+		func() error {
+			return deleteEntities(ks.MTLSAuths.GetAll, func(e *state.MTLSAuth) *string { return e.ID }, ks.MTLSAuths.Delete)
+		},
+		func() error {
+			return deleteEntities(ks.Consumers.GetAll, func(e *state.Consumer) *string { return e.ID }, ks.Consumers.Delete)
+		},
+	} {
+		if err := deleteFn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteEntities deletes all entities returned by getAll by their IDs using del.
+func deleteEntities[T any](getAll func() ([]*T, error), id func(*T) *string, del func(string) error) error {
+	entities, err := getAll()
+	if err != nil {
+		return err
+	}
+	for _, e := range entities {
+		if err := del(lo.FromPtr(id(e))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package sendconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/state"
+	"github.com/samber/lo"
+)
+
+// EntityFailure describes an entity that failed to be synced in DB mode.
+type EntityFailure struct {
+	// Operation is the failed operation ("create", "update" or "delete").
+	Operation string
+	// Kind is the kind of the entity as named by decK (e.g. "service" or "route").
+	Kind string
+	// Name identifies the entity.
+	Name string
+	// Message describes the failure.
+	Message string
+}
+
+// PartialUpdateError is returned from UpdateStrategyDBMode.Update when syncing some of the entities failed.
+// Changes other than the failed ones may have been applied, hence Kong's configuration may be partially updated
+// unless the changes have been rolled back.
+type PartialUpdateError struct {
+	// Applied summarizes changes that were applied successfully.
+	Applied DiffSummary
+	// Failed are entities that failed to be synced. Failures not associated with an entity are not included.
+	Failed []EntityFailure
+	// RolledBack tells whether the applied changes have been rolled back (see UpdateStrategyDBMode.WithRollback).
+	RolledBack bool
+	// Err is the error returned by decK's syncer.
+	Err error
+}
+
+func (e PartialUpdateError) Error() string {
+	if e.RolledBack {
+		return fmt.Sprintf("configuration update rolled back (%d entities failed): %v", len(e.Failed), e.Err)
+	}
+	return fmt.Sprintf("configuration partially applied (%d changes applied, %d entities failed): %v",
+		e.Applied.Total(), len(e.Failed), e.Err,
+	)
+}
+
+func (e PartialUpdateError) Unwrap() error {
+	return e.Err
+}
+
+// entityFailureRegex matches errors returned by decK's syncer for a failed entity operation,
+// e.g. "while processing event: {Create} service foo failed: HTTP status 400".
+var entityFailureRegex = regexp.MustCompile(`(?s)\{?(Create|Update|Delete)\}? (\S+) (.+?) failed: (.*)$`)
+
+// parseEntityFailures extracts failed entity operations from errors returned by decK's syncer.
+func parseEntityFailures(errs []error) []EntityFailure {
+	var failures []EntityFailure
+	for _, err := range errs {
+		m := entityFailureRegex.FindStringSubmatch(err.Error())
+		if m == nil {
+			continue
+		}
+		failures = append(failures, EntityFailure{
+			Operation: strings.ToLower(m[1]),
+			Kind:      m[2],
+			Name:      m[3],
+			Message:   m[4],
+		})
+	}
+	return failures
+}
+
+// entityFailuresToResourceErrors associates failed entities with Kubernetes resources they were generated from,
+// using tags of the entities in the target state. Failures of entities without Kubernetes metadata in their tags
+// (e.g. deleted entities not present in the target state) are skipped.
+func entityFailuresToResourceErrors(failures []EntityFailure, targetState *state.KongState, logger logr.Logger) []ResourceError {
+	if len(failures) == 0 || targetState == nil {
+		return nil
+	}
+
+	tagsByEntity := entityTagsByKindAndName(targetState, logger)
+	var resourceErrors []ResourceError
+	for _, f := range failures {
+		tags, ok := tagsByEntity[f.Kind+":"+f.Name]
+		if !ok {
+			continue
+		}
+		resourceError, err := parseRawResourceError(rawResourceError{
+			Name:     f.Name,
+			Tags:     tags,
+			Problems: map[string]string{fmt.Sprintf("%s:%s", f.Kind, f.Name): f.Message},
+		})
+		if err != nil {
+			logger.Error(err, "Entity tags missing fields", "kind", f.Kind, "name", f.Name)
+			continue
+		}
+		resourceErrors = append(resourceErrors, resourceError)
+	}
+	return resourceErrors
+}
+
+// entityTagsByKindAndName indexes tags of the state's entities by their decK kind and console name (as reported
+// in decK's syncer errors). Only kinds of entities generated from Kubernetes resources are indexed.
+func entityTagsByKindAndName(s *state.KongState, logger logr.Logger) map[string][]string {
+	index := map[string][]string{}
+	add := func(kind, name string, tags []*string) {
+		index[kind+":"+name] = lo.Map(tags, func(t *string, _ int) string { return lo.FromPtr(t) })
+	}
+	logErr := func(kind string, err error) {
+		logger.Error(err, "Failed to list entities of the target state", "kind", kind)
+	}
+
+	if services, err := s.Services.GetAll(); err != nil {
+		logErr("service", err)
+	} else {
+		for _, e := range services {
+			add("service", e.Console(), e.Tags)
+		}
+	}
+	if routes, err := s.Routes.GetAll(); err != nil {
+		logErr("route", err)
+	} else {
+		for _, e := range routes {
+			add("route", e.Console(), e.Tags)
+		}
+	}
+	if plugins, err := s.Plugins.GetAll(); err != nil {
+		logErr("plugin", err)
+	} else {
+		for _, e := range plugins {
+			add("plugin", e.Console(), e.Tags)
+		}
+	}
+	if upstreams, err := s.Upstreams.GetAll(); err != nil {
+		logErr("upstream", err)
+	} else {
+		for _, e := range upstreams {
+			add("upstream", e.Console(), e.Tags)
+		}
+	}
+	if consumers, err := s.Consumers.GetAll(); err != nil {
+		logErr("consumer", err)
+	} else {
+		for _, e := range consumers {
+			add("consumer", e.Console(), e.Tags)
+		}
+	}
+	if certificates, err := s.Certificates.GetAll(); err != nil {
+		logErr("certificate", err)
+	} else {
+		for _, e := range certificates {
+			add("certificate", e.Console(), e.Tags)
+		}
+	}
+	return index
+}
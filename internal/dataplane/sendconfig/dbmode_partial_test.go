@@ -0,0 +1,160 @@
+package sendconfig_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/dump"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/versions"
+)
+
+// failingRouteHandler wraps a fake Admin API handler, rejecting creation of the route with the given name.
+type failingRouteHandler struct {
+	http.Handler
+	routeName string
+}
+
+func (h failingRouteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPut || r.Method == http.MethodPost {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if bytes.Contains(body, []byte(`"name":"`+h.routeName+`"`)) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"schema violation (paths: invalid path)"}`))
+			return
+		}
+	}
+	h.Handler.ServeHTTP(w, r)
+}
+
+func TestUpdateStrategyDBMode_PartialUpdate(t *testing.T) {
+	server := httptest.NewServer(failingRouteHandler{
+		Handler:   newFakeAdminAPIHandler(t, 0),
+		routeName: "service-1-route",
+	})
+	t.Cleanup(server.Close)
+	client, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+	strategy := sendconfig.NewUpdateStrategyDBMode(
+		client, dump.Config{}, semver.MustParse("3.4.0"), 10, logr.Discard(),
+	)
+
+	content := largeContent(2)
+	content.Services[1].Routes[0].Tags = kong.StringSlice(
+		"k8s-name:ingress-1", "k8s-namespace:default", "k8s-kind:Ingress", "k8s-group:networking.k8s.io",
+		"k8s-version:v1", "k8s-uid:4f0a3b0e-1a4e-4bb4-b0b5-6c0c3c7f2e51",
+	)
+	_, err, resourceErrors, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: content})
+	require.Error(t, err)
+
+	var partialErr sendconfig.PartialUpdateError
+	require.True(t, errors.As(err, &partialErr))
+	require.Len(t, partialErr.Failed, 1)
+	require.Equal(t, "create", partialErr.Failed[0].Operation)
+	require.Equal(t, "route", partialErr.Failed[0].Kind)
+	require.Equal(t, "service-1-route", partialErr.Failed[0].Name)
+	require.Contains(t, partialErr.Failed[0].Message, "invalid path")
+	require.Equal(t, 3, partialErr.Applied.Creating, "both services and the other route should be created")
+
+	require.Len(t, resourceErrors, 1, "only the resource of the failed entity should be reported")
+	require.Equal(t, "ingress-1", resourceErrors[0].Name)
+	require.Equal(t, "default", resourceErrors[0].Namespace)
+	require.Equal(t, "Ingress", resourceErrors[0].Kind)
+	require.Equal(t, "4f0a3b0e-1a4e-4bb4-b0b5-6c0c3c7f2e51", resourceErrors[0].UID)
+}
+
+func TestPerformUpdate_PartialUpdate(t *testing.T) {
+	client, _ := newRecordingClient(t, failingRouteHandler{
+		Handler:   newFakeAdminAPIHandler(t, 0),
+		routeName: "service-1-route",
+	})
+	client.SetLastConfigSHA([]byte("last-sha"))
+	config := sendconfig.Config{Version: versions.KICv3VersionCutoff, Concurrency: 10}
+
+	result, _, err := sendconfig.PerformUpdate(
+		context.Background(), logr.Discard(), client, config, largeContent(2), metrics.NewCtrlFuncMetrics(),
+		sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()), staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.ErrorAs(t, err, &sendconfig.PartialUpdateError{})
+	require.Nil(t, result.ConfigSHA, "SHA of a partially applied configuration shouldn't be cached")
+	require.Len(t, result.EntityFailures, 1)
+	require.Equal(t, "service-1-route", result.EntityFailures[0].Name)
+	diff, ok := result.Diff.Get()
+	require.True(t, ok, "applied changes should be reported")
+	require.Positive(t, diff.Creating)
+}
+
+func TestUpdateStrategyDBMode_Rollback(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		rollback             bool
+		expectedRolledBack   bool
+		expectedServices     int
+		expectedService0Host string
+	}{
+		{
+			name:                 "changes are rolled back",
+			rollback:             true,
+			expectedRolledBack:   true,
+			expectedServices:     1,
+			expectedService0Host: "service-0.default.svc",
+		},
+		{
+			name:                 "changes are kept without rollback",
+			rollback:             false,
+			expectedRolledBack:   false,
+			expectedServices:     2,
+			expectedService0Host: "service-0.changed.svc",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newFakeAdminAPIHandler(t, 0)
+			server := httptest.NewServer(failingRouteHandler{
+				Handler:   handler,
+				routeName: "service-1-route",
+			})
+			t.Cleanup(server.Close)
+			client, err := kong.NewClient(kong.String(server.URL), server.Client())
+			require.NoError(t, err)
+			strategy := sendconfig.NewUpdateStrategyDBMode(
+				client, dump.Config{}, semver.MustParse("3.4.0"), 10, logr.Discard(),
+			).WithRollback(tc.rollback)
+
+			_, err, _, _ = strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: largeContent(1)})
+			require.NoError(t, err)
+
+			content := largeContent(2)
+			content.Services[0].Host = kong.String("service-0.changed.svc")
+			_, err, _, _ = strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: content})
+			var partialErr sendconfig.PartialUpdateError
+			require.ErrorAs(t, err, &partialErr)
+			require.Equal(t, tc.expectedRolledBack, partialErr.RolledBack)
+
+			handler.lock.Lock()
+			defer handler.lock.Unlock()
+			require.Len(t, handler.entities["services"], tc.expectedServices)
+			require.Len(t, handler.entities["routes"], 1)
+			for _, service := range handler.entities["services"] {
+				if service["name"] == "service-0" {
+					require.Equal(t, tc.expectedService0Host, service["host"])
+				}
+			}
+		})
+	}
+}
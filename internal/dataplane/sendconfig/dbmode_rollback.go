@@ -0,0 +1,81 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/deck/diff"
+	"github.com/kong/deck/state"
+	deckutils "github.com/kong/deck/utils"
+)
+
+// WithRollback returns a copy of the strategy that, when rollback is true, snapshots the current state before every
+// sync and, when syncing some of the entities fails, syncs the snapshot back to roll Kong back to the configuration
+// it held before the update. The rollback is best-effort (it may fail as well, e.g. when Kong is unreachable) and
+// costs an extra state dump per update, hence it's disabled by default.
+func (s UpdateStrategyDBMode) WithRollback(rollback bool) UpdateStrategyDBMode {
+	s.rollback = rollback
+	return s
+}
+
+// snapshot dumps the current state bypassing the strategy's StateDumper, so that the snapshot is not shared with
+// the current state used (and modified) by the syncer when the StateDumper caches it.
+func (s UpdateStrategyDBMode) snapshot(ctx context.Context) (*state.KongState, error) {
+	release, _, err := s.dumpLimiter.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for taking a snapshot of the current state for %s: %w", s.client.BaseRootURL(), err)
+	}
+	defer release()
+
+	snapshot, err := DeckStateDumper{}.Get(ctx, s.client, s.dumpConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed taking a snapshot of the current state for %s: %w", s.client.BaseRootURL(), err)
+	}
+	return s.scopeState(snapshot)
+}
+
+// rollBack syncs the snapshot taken before a failed update back to Kong. It logs the attempt and its outcome.
+func (s UpdateStrategyDBMode) rollBack(ctx context.Context, snapshot *state.KongState) bool {
+	if ctx.Err() != nil {
+		s.logger.Error(ctx.Err(), "Skipping configuration rollback as the update has been canceled")
+		return false
+	}
+
+	s.logger.Info("Configuration update failed, rolling back to the configuration held before the update")
+	if err := s.syncSnapshot(ctx, snapshot); err != nil {
+		s.logger.Error(err, "Failed to roll back configuration, Kong may be left with a partially applied configuration")
+		return false
+	}
+	s.logger.Info("Rolled back configuration to the one held before the update")
+	return true
+}
+
+func (s UpdateStrategyDBMode) syncSnapshot(ctx context.Context, snapshot *state.KongState) error {
+	// Current state has been invalidated after the failed update, so it's dumped again.
+	cs, err := s.CurrentState(ctx)
+	if err != nil {
+		return err
+	}
+	if cs, err = s.scopeState(cs); err != nil {
+		return err
+	}
+
+	syncer, err := diff.NewSyncer(s.syncerOptions.apply(diff.SyncerOpts{
+		CurrentState:  cs,
+		TargetState:   snapshot,
+		KongClient:    s.client,
+		IsKonnect:     s.isKonnect,
+		CreatePrintln: s.logEntityChange,
+		UpdatePrintln: s.logEntityChange,
+		DeletePrintln: s.logEntityChange,
+	}))
+	if err != nil {
+		return fmt.Errorf("creating a new syncer for %s: %w", s.client.BaseRootURL(), err)
+	}
+
+	_, errs, _ := syncer.Solve(ctx, s.concurrency, false, false)
+	if errs != nil {
+		return deckutils.ErrArray{Errors: errs}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package sendconfig
+
+import (
+	"github.com/kong/deck/diff"
+)
+
+// DeckSyncerOptions are options of decK's syncer used in DB mode that are safe to be tuned by operators.
+// The states being synced and the clients used for that are always controlled by the controller.
+// Zero values keep decK's syncer behavior the controller uses by default.
+type DeckSyncerOptions struct {
+	// StageDelaySeconds is the delay decK's syncer waits for between stages of a sync (e.g. after creating
+	// services and before creating routes), which can give Kong instances backed by an eventually consistent
+	// database time to propagate the changes. Note that a sync consists of a stage per every entity type.
+	StageDelaySeconds int
+
+	// EmitWarnings makes decK's syncer print its warnings (e.g. about entities relying on deprecated features)
+	// to the standard output. They're silenced by default.
+	EmitWarnings bool
+}
+
+// apply returns syncer options with the tunable ones overridden by o.
+func (o DeckSyncerOptions) apply(opts diff.SyncerOpts) diff.SyncerOpts {
+	opts.SilenceWarnings = !o.EmitWarnings
+	opts.StageDelaySec = o.StageDelaySeconds
+	return opts
+}
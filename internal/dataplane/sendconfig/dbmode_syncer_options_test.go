@@ -0,0 +1,29 @@
+package sendconfig
+
+import (
+	"testing"
+
+	"github.com/kong/deck/diff"
+	"github.com/kong/deck/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeckSyncerOptions_Apply(t *testing.T) {
+	currentState, err := state.NewKongState()
+	require.NoError(t, err)
+	opts := diff.SyncerOpts{CurrentState: currentState, IsKonnect: true}
+
+	t.Run("defaults", func(t *testing.T) {
+		applied := DeckSyncerOptions{}.apply(opts)
+		require.True(t, applied.SilenceWarnings)
+		require.Zero(t, applied.StageDelaySec)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		applied := DeckSyncerOptions{StageDelaySeconds: 2, EmitWarnings: true}.apply(opts)
+		require.False(t, applied.SilenceWarnings)
+		require.Equal(t, 2, applied.StageDelaySec)
+		require.Same(t, currentState, applied.CurrentState, "options controlled by the controller should be kept")
+		require.True(t, applied.IsKonnect)
+	})
+}
@@ -0,0 +1,95 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/deck/diff"
+	"github.com/kong/deck/dump"
+	"github.com/kong/deck/file"
+	"github.com/kong/deck/state"
+	deckutils "github.com/kong/deck/utils"
+)
+
+// DiffSummary summarizes changes between the data-plane's current configuration and a target one.
+type DiffSummary struct {
+	// Creating is the number of entities to be created (or that were created).
+	Creating int `json:"creating"`
+	// Updating is the number of entities to be updated (or that were updated).
+	Updating int `json:"updating"`
+	// Deleting is the number of entities to be deleted (or that were deleted).
+	Deleting int `json:"deleting"`
+}
+
+// HasChanges tells whether the summary contains any changes.
+func (s DiffSummary) HasChanges() bool {
+	return s.Creating > 0 || s.Updating > 0 || s.Deleting > 0
+}
+
+// Total returns the total number of changed entities.
+func (s DiffSummary) Total() int {
+	return s.Creating + s.Updating + s.Deleting
+}
+
+func diffSummaryFromStats(stats diff.Stats) DiffSummary {
+	return DiffSummary{
+		Creating: int(stats.CreateOps.Count()),
+		Updating: int(stats.UpdateOps.Count()),
+		Deleting: int(stats.DeleteOps.Count()),
+	}
+}
+
+// DiffContents computes changes that would be made to a data-plane's configuration if it was changed from current
+// to target, without involving Kong at all. Both configurations are rendered for the given Kong version.
+// As no Kong instance is queried, plugins' default config values are not filled in, so pushing the configuration
+// may result in a slightly different diff (e.g. when a plugin's config only differs by its defaults).
+func DiffContents(ctx context.Context, current, target *file.Content, kongVersion semver.Version) (DiffSummary, error) {
+	emptyState, err := state.NewKongState()
+	if err != nil {
+		return DiffSummary{}, fmt.Errorf("creating a new state: %w", err)
+	}
+	currentRawState, err := file.Get(ctx, current, file.RenderConfig{
+		CurrentState: emptyState,
+		KongVersion:  kongVersion,
+	}, dump.Config{}, nil)
+	if err != nil {
+		return DiffSummary{}, fmt.Errorf("building current state: %w", err)
+	}
+	cs, err := state.Get(currentRawState)
+	if err != nil {
+		return DiffSummary{}, fmt.Errorf("building current state: %w", err)
+	}
+
+	// Target state is rendered against the current one so that IDs of entities existing in both are matched.
+	targetRawState, err := file.Get(ctx, target, file.RenderConfig{
+		CurrentState: cs,
+		KongVersion:  kongVersion,
+	}, dump.Config{}, nil)
+	if err != nil {
+		return DiffSummary{}, fmt.Errorf("building target state: %w", err)
+	}
+	ts, err := state.Get(targetRawState)
+	if err != nil {
+		return DiffSummary{}, fmt.Errorf("building target state: %w", err)
+	}
+
+	syncer, err := diff.NewSyncer(diff.SyncerOpts{
+		CurrentState:    cs,
+		TargetState:     ts,
+		SilenceWarnings: true,
+		CreatePrintln:   func(...any) {},
+		UpdatePrintln:   func(...any) {},
+		DeletePrintln:   func(...any) {},
+	})
+	if err != nil {
+		return DiffSummary{}, fmt.Errorf("creating a new syncer: %w", err)
+	}
+
+	stats, errs, _ := syncer.Solve(ctx, 1, true, false)
+	if errs != nil {
+		return DiffSummary{}, deckutils.ErrArray{Errors: errs}
+	}
+
+	return diffSummaryFromStats(stats), nil
+}
@@ -0,0 +1,77 @@
+package sendconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+func TestDiffSummary(t *testing.T) {
+	t.Run("empty summary has no changes", func(t *testing.T) {
+		s := sendconfig.DiffSummary{}
+		require.False(t, s.HasChanges())
+		require.Zero(t, s.Total())
+	})
+
+	t.Run("summary with changes", func(t *testing.T) {
+		s := sendconfig.DiffSummary{Creating: 1, Updating: 2, Deleting: 3}
+		require.True(t, s.HasChanges())
+		require.Equal(t, 6, s.Total())
+	})
+
+	t.Run("summary is JSON serializable", func(t *testing.T) {
+		b, err := json.Marshal(sendconfig.DiffSummary{Creating: 1, Updating: 2, Deleting: 3})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"creating":1,"updating":2,"deleting":3}`, string(b))
+	})
+}
+
+func TestDiffContents(t *testing.T) {
+	current := &file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{
+				Service: kong.Service{Name: kong.String("service-a"), Host: kong.String("a.example.com")},
+				Routes: []*file.FRoute{
+					{Route: kong.Route{Name: kong.String("route-a"), Paths: kong.StringSlice("/a")}},
+				},
+			},
+			{
+				Service: kong.Service{Name: kong.String("service-b"), Host: kong.String("b.example.com")},
+			},
+		},
+	}
+	target := &file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{
+				Service: kong.Service{Name: kong.String("service-a"), Host: kong.String("a2.example.com")},
+				Routes: []*file.FRoute{
+					{Route: kong.Route{Name: kong.String("route-a"), Paths: kong.StringSlice("/a")}},
+				},
+			},
+			{
+				Service: kong.Service{Name: kong.String("service-c"), Host: kong.String("c.example.com")},
+			},
+		},
+	}
+
+	t.Run("changed contents", func(t *testing.T) {
+		summary, err := sendconfig.DiffContents(context.Background(), current, target, semver.MustParse("3.4.0"))
+		require.NoError(t, err)
+		require.Equal(t, sendconfig.DiffSummary{Creating: 1, Updating: 1, Deleting: 1}, summary)
+	})
+
+	t.Run("equal contents", func(t *testing.T) {
+		summary, err := sendconfig.DiffContents(context.Background(), current, current, semver.MustParse("3.4.0"))
+		require.NoError(t, err)
+		require.False(t, summary.HasChanges())
+	})
+}
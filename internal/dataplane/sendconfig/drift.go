@@ -0,0 +1,87 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/diff"
+	"github.com/kong/deck/file"
+	deckutils "github.com/kong/deck/utils"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+// EntityDrift describes an entity whose state in the data-plane diverged from the configuration applied to it.
+type EntityDrift struct {
+	// Operation is the operation ("create", "update" or "delete") that would revert the drift.
+	Operation string
+	// Kind is the kind of the entity as named by decK (e.g. "service" or "route").
+	Kind string
+	// Name identifies the entity.
+	Name string
+}
+
+func (d EntityDrift) String() string {
+	return fmt.Sprintf("%s %s %s", d.Operation, d.Kind, d.Name)
+}
+
+// Drift returns entities whose current state differs from targetContent, without applying any changes.
+func (s UpdateStrategyDBMode) Drift(ctx context.Context, targetContent *file.Content) ([]EntityDrift, error) {
+	syncer, _, _, err := s.newSyncer(ctx, targetContent, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, errs, changes := syncer.Solve(ctx, s.concurrencyFor(targetContent), true, true)
+	if errs != nil {
+		return nil, deckutils.ErrArray{Errors: errs}
+	}
+
+	var drift []EntityDrift
+	for _, c := range []struct {
+		operation string
+		entities  []diff.EntityState
+	}{
+		{operation: "create", entities: changes.Creating},
+		{operation: "update", entities: changes.Updating},
+		{operation: "delete", entities: changes.Deleting},
+	} {
+		for _, e := range c.entities {
+			drift = append(drift, EntityDrift{Operation: c.operation, Kind: e.Kind, Name: e.Name})
+		}
+	}
+	return drift, nil
+}
+
+// checkDrift compares the freshly dumped current state of the data-plane with targetContent (that is expected
+// to be already applied) and reports differences, i.e. changes made to the data-plane out-of-band. Failures of
+// the check are only logged as they do not affect the configuration update.
+func checkDrift(
+	ctx context.Context,
+	logger logr.Logger,
+	client AdminAPIClient,
+	config Config,
+	targetContent *file.Content,
+	promMetrics *metrics.CtrlFuncMetrics,
+) {
+	// Cached state would not reflect out-of-band changes, hence it's always dumped.
+	strategy := newUpdateStrategyDBModeForClient(client, config, logger).WithStateDumper(DeckStateDumper{})
+	drift, err := strategy.Drift(ctx, targetContent)
+	if err != nil {
+		logger.Error(err, "Failed to check configuration drift")
+		return
+	}
+	if len(drift) == 0 {
+		return
+	}
+
+	promMetrics.RecordConfigDriftDetected(client.BaseRootURL())
+	driftedEntities := make([]string, 0, len(drift))
+	for _, d := range drift {
+		driftedEntities = append(driftedEntities, d.String())
+	}
+	logger.Error(nil, "Configuration drift detected, data-plane's configuration was changed out-of-band",
+		"drifted_entities", driftedEntities,
+	)
+}
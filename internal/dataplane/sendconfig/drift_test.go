@@ -0,0 +1,81 @@
+package sendconfig_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"github.com/kong/deck/dump"
+	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+func TestUpdateStrategyDBMode_Drift(t *testing.T) {
+	server := httptest.NewServer(newFakeAdminAPIHandler(t, 0))
+	t.Cleanup(server.Close)
+	client, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+	strategy := sendconfig.NewUpdateStrategyDBMode(
+		client, dump.Config{}, semver.MustParse("3.4.0"), 10, logr.Discard(),
+	)
+	ctx := context.Background()
+
+	_, err, _, _ = strategy.Update(ctx, sendconfig.ContentWithHash{Content: largeContent(2)})
+	require.NoError(t, err)
+	drift, err := strategy.Drift(ctx, largeContent(2))
+	require.NoError(t, err)
+	require.Empty(t, drift)
+
+	drift, err = strategy.Drift(ctx, largeContent(1))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []sendconfig.EntityDrift{
+		{Operation: "delete", Kind: "service", Name: "service-1"},
+		{Operation: "delete", Kind: "route", Name: "service-1-route"},
+	}, drift)
+}
+
+func TestPerformUpdate_DetectDrift(t *testing.T) {
+	server := httptest.NewServer(newFakeAdminAPIHandler(t, 0))
+	t.Cleanup(server.Close)
+	kongClient, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+	client := adminapi.NewClient(kongClient)
+
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zapr.NewLogger(zap.New(core))
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	config := sendconfig.Config{Version: semver.MustParse("3.4.0"), Concurrency: 10, DetectDrift: true}
+	performUpdate := func(hasChanged bool) {
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logger, client, config, largeContent(2),
+			promMetrics, sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()),
+			staticConfigurationChangeDetector{hasChanged: hasChanged},
+		)
+		require.NoError(t, err)
+	}
+
+	t.Log("no drift is reported when the configuration is intact")
+	performUpdate(true)
+	performUpdate(false)
+	require.Zero(t, testutil.CollectAndCount(promMetrics.ConfigDriftDetectedCount))
+
+	t.Log("drift is reported after the configuration was changed out-of-band")
+	_, err, _, _ = sendconfig.NewUpdateStrategyDBMode(kongClient, dump.Config{}, semver.MustParse("3.4.0"), 10, logr.Discard()).
+		Update(context.Background(), sendconfig.ContentWithHash{Content: largeContent(1)})
+	require.NoError(t, err)
+	performUpdate(false)
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigDriftDetectedCount.WithLabelValues(client.BaseRootURL())))
+	driftLogs := logs.FilterMessageSnippet("Configuration drift detected").All()
+	require.Len(t, driftLogs, 1)
+	require.ElementsMatch(t, []string{"create service service-1", "create route service-1-route"},
+		driftLogs[0].ContextMap()["drifted_entities"])
+}
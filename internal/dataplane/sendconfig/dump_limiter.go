@@ -0,0 +1,37 @@
+package sendconfig
+
+import (
+	"context"
+	"time"
+)
+
+// DumpLimiter limits the number of concurrent dumps of Kong's current state (see UpdateStrategyDBMode.CurrentState).
+// Every dump issues a request per page of every entity type, so dumps of multiple Kong instances running at
+// the same time can overwhelm an Admin API backend shared by them (e.g. a database). A single DumpLimiter is meant to
+// be shared by all the Kong instances. A nil DumpLimiter doesn't limit dumps.
+type DumpLimiter struct {
+	slots chan struct{}
+}
+
+// NewDumpLimiter returns a DumpLimiter allowing up to limit concurrent dumps.
+func NewDumpLimiter(limit int) *DumpLimiter {
+	return &DumpLimiter{
+		slots: make(chan struct{}, limit),
+	}
+}
+
+// acquire blocks until a dump is allowed or the context is done. It returns the time spent waiting and a function
+// that has to be called once the dump is done.
+func (l *DumpLimiter) acquire(ctx context.Context) (release func(), wait time.Duration, err error) {
+	if l == nil {
+		return func() {}, 0, nil
+	}
+
+	start := time.Now()
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, time.Since(start), nil
+	case <-ctx.Done():
+		return nil, time.Since(start), ctx.Err()
+	}
+}
@@ -0,0 +1,46 @@
+package sendconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpLimiter(t *testing.T) {
+	t.Run("nil doesn't limit dumps", func(t *testing.T) {
+		var l *DumpLimiter
+		release, wait, err := l.acquire(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, wait)
+		release()
+	})
+
+	t.Run("dumps over the limit wait for a release", func(t *testing.T) {
+		l := NewDumpLimiter(1)
+		release, _, err := l.acquire(context.Background())
+		require.NoError(t, err)
+
+		const holdFor = 50 * time.Millisecond
+		go func() {
+			time.Sleep(holdFor)
+			release()
+		}()
+		release, wait, err := l.acquire(context.Background())
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, wait, holdFor)
+		release()
+	})
+
+	t.Run("waiting is aborted when context is done", func(t *testing.T) {
+		l := NewDumpLimiter(1)
+		_, _, err := l.acquire(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err = l.acquire(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
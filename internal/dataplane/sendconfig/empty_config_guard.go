@@ -0,0 +1,88 @@
+package sendconfig
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kong/deck/file"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+)
+
+// EmptyConfigGuardError is returned from PerformUpdate when EmptyConfigGuard refuses to push a configuration.
+type EmptyConfigGuardError struct {
+	// Entities is the number of entities in the refused configuration.
+	Entities int
+	// AppliedEntities is the number of entities in the configuration last applied to the data-plane.
+	AppliedEntities int
+}
+
+func (e EmptyConfigGuardError) Error() string {
+	return fmt.Sprintf("refusing to push configuration with %d entities as the configuration last applied had %d entities, "+
+		"it would remove most of Kong's configuration (set --allow-empty-config to push it anyway)",
+		e.Entities, e.AppliedEntities,
+	)
+}
+
+// EmptyConfigGuard refuses pushes of configurations that are empty, or that have fewer entities than minFraction of
+// the configuration last applied to a data-plane, as pushing them would wipe (most of) Kong's configuration, most
+// likely by accident (e.g. due to all Ingresses being deleted or a translation bug). Pushes to a data-plane no
+// configuration has been applied to yet by the controller are always allowed. A nil EmptyConfigGuard allows all pushes.
+type EmptyConfigGuard struct {
+	minFraction float64
+
+	lock    sync.Mutex
+	applied map[string]int // Entity counts of configurations last applied keyed by data-plane URL.
+}
+
+// NewEmptyConfigGuard returns an EmptyConfigGuard refusing empty configurations and, when minFraction is positive,
+// configurations with fewer entities than minFraction of the configuration last applied.
+func NewEmptyConfigGuard(minFraction float64) *EmptyConfigGuard {
+	return &EmptyConfigGuard{
+		minFraction: minFraction,
+		applied:     map[string]int{},
+	}
+}
+
+// check returns EmptyConfigGuardError when the content shouldn't be pushed to the data-plane.
+func (g *EmptyConfigGuard) check(dataplane string, content *file.Content) error {
+	if g == nil {
+		return nil
+	}
+
+	g.lock.Lock()
+	applied, ok := g.applied[dataplane]
+	g.lock.Unlock()
+	if !ok || applied == 0 {
+		return nil
+	}
+
+	entities := guardedEntityCount(content)
+	if entities == 0 || float64(entities) < g.minFraction*float64(applied) {
+		return EmptyConfigGuardError{Entities: entities, AppliedEntities: applied}
+	}
+	return nil
+}
+
+// configApplied records the content applied to the data-plane.
+func (g *EmptyConfigGuard) configApplied(dataplane string, content *file.Content) {
+	if g == nil {
+		return
+	}
+
+	entities := guardedEntityCount(content)
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.applied[dataplane] = entities
+}
+
+// guardedEntityCount returns the number of entities in the content. Content holding only the stub upstream that's
+// added to empty configurations (see deckgen.GenerationParams.AppendStubEntityWhenConfigEmpty) is considered empty.
+func guardedEntityCount(content *file.Content) int {
+	entities := deckgen.CountEntities(content)
+	if entities == 1 && len(content.Upstreams) == 1 && lo.FromPtr(content.Upstreams[0].Name) == deckgen.StubUpstreamName {
+		return 0
+	}
+	return entities
+}
@@ -0,0 +1,54 @@
+package sendconfig
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+)
+
+func TestEmptyConfigGuard(t *testing.T) {
+	const dataplane = "https://kong:8444"
+	contentWithServices := func(count int) *file.Content {
+		content := &file.Content{}
+		for i := 0; i < count; i++ {
+			content.Services = append(content.Services, file.FService{Service: kong.Service{Name: kong.String(string(rune('a' + i)))}})
+		}
+		return content
+	}
+	stubContent := &file.Content{Upstreams: []file.FUpstream{{Upstream: kong.Upstream{Name: lo.ToPtr(deckgen.StubUpstreamName)}}}}
+
+	t.Run("nil allows all pushes", func(t *testing.T) {
+		var g *EmptyConfigGuard
+		g.configApplied(dataplane, contentWithServices(10))
+		require.NoError(t, g.check(dataplane, &file.Content{}))
+	})
+
+	t.Run("empty configuration is allowed until configuration is applied", func(t *testing.T) {
+		g := NewEmptyConfigGuard(0)
+		require.NoError(t, g.check(dataplane, &file.Content{}))
+		g.configApplied(dataplane, &file.Content{})
+		require.NoError(t, g.check(dataplane, &file.Content{}), "empty configuration should be allowed after an empty one")
+	})
+
+	t.Run("empty configuration is refused after configuration is applied", func(t *testing.T) {
+		g := NewEmptyConfigGuard(0)
+		g.configApplied(dataplane, contentWithServices(10))
+		require.ErrorIs(t, g.check(dataplane, &file.Content{}), EmptyConfigGuardError{Entities: 0, AppliedEntities: 10})
+		require.ErrorIs(t, g.check(dataplane, stubContent), EmptyConfigGuardError{Entities: 0, AppliedEntities: 10},
+			"configuration holding only the stub upstream should be considered empty")
+		require.NoError(t, g.check(dataplane, contentWithServices(1)))
+		require.NoError(t, g.check("https://other-kong:8444", &file.Content{}), "other data-planes shouldn't be affected")
+	})
+
+	t.Run("configuration much smaller than the one applied is refused", func(t *testing.T) {
+		g := NewEmptyConfigGuard(0.5)
+		g.configApplied(dataplane, contentWithServices(10))
+		require.ErrorIs(t, g.check(dataplane, contentWithServices(4)), EmptyConfigGuardError{Entities: 4, AppliedEntities: 10})
+		require.NoError(t, g.check(dataplane, contentWithServices(5)))
+	})
+}
@@ -1,11 +1,16 @@
 package sendconfig
 
 import (
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/zapr"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
 )
 
 func TestParseFlatEntityErrors(t *testing.T) {
@@ -166,3 +171,114 @@ func TestParseFlatEntityErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSanitizedErrorBody(t *testing.T) {
+	tests := []struct {
+		name                      string
+		body                      []byte
+		additionalSensitiveFields []string
+		want                      string
+	}{
+		{
+			name: "credential fields are redacted",
+			body: []byte(`{"code":14,"fields":{"consumers":[{"keyauth_credentials":[{"key":"very-secret","tags":["a"]}],` +
+				`"basicauth_credentials":[{"username":"user","password":"pass"}]}]}}`),
+			want: `{"code":14,"fields":{"consumers":[{"basicauth_credentials":[{"password":"REDACTED","username":"user"}],` +
+				`"keyauth_credentials":[{"key":"REDACTED","tags":["a"]}]}]}}`,
+		},
+		{
+			name: "non-string values of sensitive fields are traversed",
+			body: []byte(`{"fields":{"key":{"secret":"s3cr3t"}}}`),
+			want: `{"fields":{"key":{"secret":"REDACTED"}}}`,
+		},
+		{
+			name:                      "additional sensitive fields are redacted",
+			body:                      []byte(`{"fields":{"plugins":[{"config":{"api_token":"s3cr3t","header":"x-token"}}]}}`),
+			additionalSensitiveFields: []string{"api_token"},
+			want:                      `{"fields":{"plugins":[{"config":{"api_token":"REDACTED","header":"x-token"}}]}}`,
+		},
+		{
+			name: "non-JSON body is returned as is",
+			body: []byte(`upstream connect error`),
+			want: `upstream connect error`,
+		},
+		{
+			name: "long body is truncated",
+			body: []byte(strings.Repeat("a", maxErrorBodyLength+1)),
+			want: strings.Repeat("a", maxErrorBodyLength) + "...(truncated)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, sanitizedErrorBody(tt.body, tt.additionalSensitiveFields))
+		})
+	}
+}
+
+func TestWrapConfigError(t *testing.T) {
+	baseErr := errors.New("HTTP status 400 (message: \"declarative config is invalid\")")
+
+	t.Run("flattened errors are enumerated", func(t *testing.T) {
+		body := []byte(`{
+  "code": 14,
+  "name": "invalid declarative configuration",
+  "flattened_errors": [
+    {
+      "entity_type": "route",
+      "entity_name": "default.httpbin.httpbin..80",
+      "entity_tags": ["k8s-name:httpbin"],
+      "errors": [
+        {"field": "methods", "type": "field", "message": "cannot set methods when protocols is grpc or grpcs"},
+        {"field": "paths", "type": "field", "messages": ["", "should start with: /"]}
+      ]
+    },
+    {
+      "entity_type": "service",
+      "entity_id": "e7e5c93e-4d56-4cc3-8f4f-ff1fcbe95eb2",
+      "errors": [
+        {"type": "entity", "message": "failed conditional validation given value of field protocol"}
+      ]
+    }
+  ]
+}`)
+
+		err := wrapConfigError(baseErr, 0, body, nil)
+		var invalidConfigErr InvalidConfigError
+		require.ErrorAs(t, err, &invalidConfigErr)
+		require.ErrorIs(t, err, baseErr)
+		require.Len(t, invalidConfigErr.Entities, 2)
+		require.Equal(t, baseErr.Error()+": "+
+			"route default.httpbin.httpbin..80: methods: cannot set methods when protocols is grpc or grpcs, paths[1]: should start with: /; "+
+			"service e7e5c93e-4d56-4cc3-8f4f-ff1fcbe95eb2: failed conditional validation given value of field protocol",
+			err.Error(),
+		)
+	})
+
+	t.Run("body without flattened errors is appended", func(t *testing.T) {
+		err := wrapConfigError(baseErr, 0, []byte(`{"message":"invalid","fields":{"services":[{"path":"value must be null"}]}}`), nil)
+		require.ErrorIs(t, err, baseErr)
+		require.False(t, errors.As(err, &InvalidConfigError{}))
+		require.Equal(t, baseErr.Error()+`: {"fields":{"services":[{"path":"value must be null"}]},"message":"invalid"}`, err.Error())
+	})
+
+	t.Run("error response status is attached", func(t *testing.T) {
+		err := wrapConfigError(baseErr, http.StatusBadRequest, []byte(`{"message":"invalid"}`), nil)
+		require.ErrorAs(t, err, &deckerrors.ConfigStatusError{})
+		require.ErrorIs(t, err, baseErr)
+		require.Equal(t, []int{http.StatusBadRequest}, deckerrors.StatusCodes(err))
+		require.Equal(t, baseErr.Error()+`: {"message":"invalid"}`, err.Error())
+		require.Empty(t, deckerrors.StatusCodes(wrapConfigError(baseErr, 0, nil, nil)), "no status without a response")
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		require.Equal(t, baseErr, wrapConfigError(baseErr, 0, nil, nil))
+	})
+
+	t.Run("service unavailable", func(t *testing.T) {
+		unavailableErr := errors.New("failed posting new config to /config: got status code 503")
+		err := wrapConfigError(unavailableErr, http.StatusServiceUnavailable, []byte(`{"message":"service unavailable"}`), nil)
+		require.ErrorIs(t, err, unavailableErr)
+		require.True(t, deckerrors.IsUnavailableErr(err))
+		require.False(t, deckerrors.IsUnavailableErr(wrapConfigError(baseErr, 0, nil, nil)))
+	})
+}
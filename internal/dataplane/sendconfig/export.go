@@ -0,0 +1,119 @@
+package sendconfig
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// FileExporter writes configuration to a file in decK's declarative format instead of pushing it to Kong,
+// e.g. to let the configuration be reviewed in a GitOps pipeline before it's applied in a separate step.
+// The Admin API is not involved at all.
+type FileExporter struct {
+	path   string
+	format file.Format
+	logger logr.Logger
+
+	lock    sync.Mutex
+	lastSHA []byte
+}
+
+// NewFileExporter creates a FileExporter writing configuration to path in the given format (file.YAML or file.JSON).
+func NewFileExporter(path string, format file.Format, logger logr.Logger) (*FileExporter, error) {
+	if format != file.YAML && format != file.JSON {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	return &FileExporter{
+		path:   path,
+		format: format,
+		logger: logger,
+	}, nil
+}
+
+// Export writes targetContent to the file, unless the configuration has not changed since it was last exported
+// (or, after a restart, the file already holds it). The file is replaced atomically, so readers never see it
+// partially written. It returns the SHA of the configuration and whether the file was written.
+func (e *FileExporter) Export(targetContent *file.Content) ([]byte, bool, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	newSHA, err := deckgen.GenerateSHA(targetContent)
+	if err != nil {
+		return nil, false, err
+	}
+	if bytes.Equal(e.lastSHA, newSHA) {
+		e.logger.V(util.DebugLevel).Info("No configuration change, skipping export", "path", e.path)
+		return newSHA, false, nil
+	}
+
+	b, err := e.serialize(targetContent)
+	if err != nil {
+		return nil, false, err
+	}
+	if e.lastSHA == nil {
+		if existing, err := os.ReadFile(e.path); err == nil && bytes.Equal(existing, b) {
+			e.lastSHA = newSHA
+			return newSHA, false, nil
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			e.logger.Error(err, "Failed to read previously exported configuration, overwriting it", "path", e.path)
+		}
+	}
+
+	if err := writeFileAtomically(e.path, b); err != nil {
+		return nil, false, fmt.Errorf("exporting configuration to %s: %w", e.path, err)
+	}
+	e.lastSHA = newSHA
+	e.logger.V(util.InfoLevel).Info("Exported configuration", "path", e.path, "config_sha", hex.EncodeToString(newSHA))
+	return newSHA, true, nil
+}
+
+func (e *FileExporter) serialize(targetContent *file.Content) ([]byte, error) {
+	if e.format == file.JSON {
+		b, err := json.MarshalIndent(targetContent, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshaling configuration to JSON: %w", err)
+		}
+		return b, nil
+	}
+	b, err := yaml.Marshal(targetContent)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling configuration to YAML: %w", err)
+	}
+	return b, nil
+}
+
+// writeFileAtomically writes b to a temporary file in the same directory as path and renames it to path,
+// which replaces the file atomically.
+func writeFileAtomically(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath) // No-op once the file was renamed.
+
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
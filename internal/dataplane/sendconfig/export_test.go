@@ -0,0 +1,89 @@
+package sendconfig_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+// untouchedModTime is a modification time set on exported files to tell whether they were written again.
+var untouchedModTime = time.Unix(0, 0)
+
+func requireModTime(t *testing.T, path string, expected time.Time) {
+	t.Helper()
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, expected.Equal(info.ModTime()), "file should not be written")
+}
+
+func TestFileExporter_Export(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kong.yaml")
+	exporter, err := sendconfig.NewFileExporter(path, file.YAML, logr.Discard())
+	require.NoError(t, err)
+
+	t.Log("configuration is written")
+	sha, written, err := exporter.Export(largeContent(1))
+	require.NoError(t, err)
+	require.True(t, written)
+	require.NotEmpty(t, sha)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	exported := &file.Content{}
+	require.NoError(t, yaml.Unmarshal(b, exported))
+	require.Equal(t, largeContent(1), exported)
+
+	t.Log("unchanged configuration is not written again")
+	require.NoError(t, os.Chtimes(path, untouchedModTime, untouchedModTime))
+	_, written, err = exporter.Export(largeContent(1))
+	require.NoError(t, err)
+	require.False(t, written)
+	requireModTime(t, path, untouchedModTime)
+
+	t.Log("changed configuration is written")
+	newSHA, written, err := exporter.Export(largeContent(2))
+	require.NoError(t, err)
+	require.True(t, written)
+	require.NotEqual(t, sha, newSHA)
+
+	t.Log("file already holding the configuration is not written after a restart")
+	require.NoError(t, os.Chtimes(path, untouchedModTime, untouchedModTime))
+	exporter, err = sendconfig.NewFileExporter(path, file.YAML, logr.Discard())
+	require.NoError(t, err)
+	_, written, err = exporter.Export(largeContent(2))
+	require.NoError(t, err)
+	require.False(t, written)
+	requireModTime(t, path, untouchedModTime)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "no temporary files should be left behind")
+}
+
+func TestFileExporter_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kong.json")
+	exporter, err := sendconfig.NewFileExporter(path, file.JSON, logr.Discard())
+	require.NoError(t, err)
+
+	_, written, err := exporter.Export(largeContent(1))
+	require.NoError(t, err)
+	require.True(t, written)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	exported := &file.Content{}
+	require.NoError(t, json.Unmarshal(b, exported))
+	require.Equal(t, largeContent(1), exported)
+}
+
+func TestNewFileExporter_UnsupportedFormat(t *testing.T) {
+	_, err := sendconfig.NewFileExporter(filepath.Join(t.TempDir(), "kong.toml"), "TOML", logr.Discard())
+	require.Error(t, err)
+}
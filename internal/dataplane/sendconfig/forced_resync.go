@@ -0,0 +1,66 @@
+package sendconfig
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ForcedResync schedules periodic forced configuration resyncs per data-plane, making PerformUpdate sync the whole
+// configuration even if its SHA hasn't changed, so that a silent drift of the data-plane's configuration is healed
+// eventually. Every resync is scheduled after the interval plus a random jitter of up to jitterFactor * interval,
+// so that multiple controller replicas don't resync simultaneously. A nil ForcedResync never forces a resync.
+type ForcedResync struct {
+	interval     time.Duration
+	jitterFactor float64
+	now          func() time.Time
+
+	lock sync.Mutex
+	due  map[string]time.Time // Keyed by data-plane URL.
+}
+
+// NewForcedResync returns a ForcedResync forcing resyncs every interval (plus a random jitter of up to
+// jitterFactor * interval).
+func NewForcedResync(interval time.Duration, jitterFactor float64) *ForcedResync {
+	return &ForcedResync{
+		interval:     interval,
+		jitterFactor: jitterFactor,
+		now:          time.Now,
+		due:          map[string]time.Time{},
+	}
+}
+
+// Due tells whether a forced resync of the data-plane is due. The first call for a data-plane schedules its first
+// resync.
+func (r *ForcedResync) Due(dataplane string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	due, ok := r.due[dataplane]
+	if !ok {
+		r.scheduleLocked(dataplane)
+		return false
+	}
+	return !r.now().Before(due)
+}
+
+// Synced schedules the next forced resync of the data-plane after its whole configuration has been synced
+// (regardless of whether the sync was forced or not).
+func (r *ForcedResync) Synced(dataplane string) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.scheduleLocked(dataplane)
+}
+
+func (r *ForcedResync) scheduleLocked(dataplane string) {
+	jitter := time.Duration(rand.Float64() * r.jitterFactor * float64(r.interval)) //nolint:gosec
+	r.due[dataplane] = r.now().Add(r.interval + jitter)
+}
@@ -0,0 +1,47 @@
+package sendconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForcedResync(t *testing.T) {
+	const dataplane = "https://kong:8444"
+
+	t.Run("nil never forces a resync", func(t *testing.T) {
+		var r *ForcedResync
+		require.False(t, r.Due(dataplane))
+		r.Synced(dataplane)
+	})
+
+	t.Run("resync is due after the interval with jitter", func(t *testing.T) {
+		now := time.Now()
+		r := NewForcedResync(time.Hour, 0.5)
+		r.now = func() time.Time { return now }
+
+		require.False(t, r.Due(dataplane), "first call should schedule the resync")
+		now = now.Add(time.Hour - time.Second)
+		require.False(t, r.Due(dataplane))
+		now = now.Add(time.Hour / 2)
+		require.True(t, r.Due(dataplane), "resync should be due after the interval with the maximum jitter")
+		require.True(t, r.Due(dataplane), "resync should stay due until the data-plane is synced")
+
+		r.Synced(dataplane)
+		require.False(t, r.Due(dataplane))
+	})
+
+	t.Run("jitter spreads resyncs of data-planes", func(t *testing.T) {
+		r := NewForcedResync(time.Hour, 1)
+		for i := 0; i < 10; i++ {
+			r.Synced(string(rune('a' + i)))
+		}
+		distinct := map[time.Time]struct{}{}
+		for _, due := range r.due {
+			require.WithinRange(t, due, time.Now().Add(time.Hour-time.Minute), time.Now().Add(2*time.Hour))
+			distinct[due] = struct{}{}
+		}
+		require.Greater(t, len(distinct), 1)
+	})
+}
@@ -0,0 +1,49 @@
+package sendconfig
+
+import (
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// logGeneratedConfig logs the generated configuration with values of sensitive fields redacted when the logger is
+// enabled at the configured verbosity (see Config.GeneratedConfigLogLevel). As the configuration may be large, it's
+// never marshaled unless it's going to be logged.
+func logGeneratedConfig(logger logr.Logger, config Config, targetContent *file.Content) {
+	if config.GeneratedConfigLogLevel <= 0 {
+		return
+	}
+	// The configuration is never logged at normal log levels, regardless of the configured verbosity.
+	logger = logger.V(max(config.GeneratedConfigLogLevel, util.TraceLevel))
+	if !logger.Enabled() {
+		return
+	}
+
+	generated, err := redactedConfig(targetContent, config.SensitiveFields)
+	if err != nil {
+		logger.Error(err, "Failed to marshal generated configuration for logging")
+		return
+	}
+	logger.Info("Generated configuration", "config", generated)
+}
+
+// redactedConfig returns the configuration marshaled to JSON with values of sensitive fields (the well-known ones
+// and additionalSensitiveFields) redacted.
+func redactedConfig(content *file.Content, additionalSensitiveFields []string) (string, error) {
+	marshaled, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	var parsed any
+	if err := json.Unmarshal(marshaled, &parsed); err != nil {
+		return "", err
+	}
+	redacted, err := json.Marshal(redactSensitiveFields(parsed, sensitiveFieldMatcher(additionalSensitiveFields)))
+	if err != nil {
+		return "", err
+	}
+	return string(redacted), nil
+}
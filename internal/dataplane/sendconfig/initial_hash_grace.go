@@ -0,0 +1,60 @@
+package sendconfig
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// InitialHashGracePeriod defers re-syncs of data-planes found to have no configuration (i.e. reporting the initial
+// configuration hash, e.g. after a restart) by a random grace period of up to maxPeriod, so that multiple controller
+// replicas observing a restarted Kong don't all push to it at once. When another replica's push lands within
+// the grace period, the data-plane no longer reports the initial hash and the re-sync isn't needed anymore.
+// A nil InitialHashGracePeriod doesn't defer re-syncs.
+type InitialHashGracePeriod struct {
+	maxPeriod time.Duration
+	now       func() time.Time
+
+	lock      sync.Mutex
+	deadlines map[string]time.Time // Keyed by data-plane URL.
+}
+
+// NewInitialHashGracePeriod returns an InitialHashGracePeriod deferring re-syncs by a random period of up to maxPeriod.
+func NewInitialHashGracePeriod(maxPeriod time.Duration) *InitialHashGracePeriod {
+	return &InitialHashGracePeriod{
+		maxPeriod: maxPeriod,
+		now:       time.Now,
+		deadlines: map[string]time.Time{},
+	}
+}
+
+// Elapsed tells whether the grace period of the data-plane found to have no configuration has elapsed, i.e. whether
+// it should be re-synced. The first call for a data-plane starts its grace period and always returns false, so that
+// the data-plane's status is checked again before it's re-synced.
+func (g *InitialHashGracePeriod) Elapsed(dataplane string) bool {
+	if g == nil {
+		return true
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	deadline, ok := g.deadlines[dataplane]
+	if !ok {
+		jitter := time.Duration(rand.Int63n(int64(g.maxPeriod) + 1)) //nolint:gosec
+		g.deadlines[dataplane] = g.now().Add(jitter)
+		return false
+	}
+	return !g.now().Before(deadline)
+}
+
+// Reset ends the grace period of the data-plane once it's found to have configuration.
+func (g *InitialHashGracePeriod) Reset(dataplane string) {
+	if g == nil {
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	delete(g.deadlines, dataplane)
+}
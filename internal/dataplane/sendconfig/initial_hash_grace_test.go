@@ -0,0 +1,45 @@
+package sendconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialHashGracePeriod(t *testing.T) {
+	const dataplane = "https://kong:8444"
+
+	t.Run("nil never defers re-syncs", func(t *testing.T) {
+		var g *InitialHashGracePeriod
+		require.True(t, g.Elapsed(dataplane))
+		g.Reset(dataplane)
+	})
+
+	t.Run("re-sync is deferred until the grace period elapses", func(t *testing.T) {
+		now := time.Now()
+		g := NewInitialHashGracePeriod(time.Minute)
+		g.now = func() time.Time { return now }
+
+		require.False(t, g.Elapsed(dataplane), "first call should start the grace period")
+		now = now.Add(time.Minute)
+		require.True(t, g.Elapsed(dataplane), "grace period should elapse after its maximum")
+		require.True(t, g.Elapsed(dataplane), "grace period should stay elapsed until reset")
+
+		g.Reset(dataplane)
+		require.False(t, g.Elapsed(dataplane), "reset should let a new grace period start")
+	})
+
+	t.Run("jitter spreads grace periods of data-planes", func(t *testing.T) {
+		g := NewInitialHashGracePeriod(time.Hour)
+		for i := 0; i < 10; i++ {
+			g.Elapsed(string(rune('a' + i)))
+		}
+		distinct := map[time.Time]struct{}{}
+		for _, deadline := range g.deadlines {
+			require.WithinRange(t, deadline, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+			distinct[deadline] = struct{}{}
+		}
+		require.Greater(t, len(distinct), 1)
+	})
+}
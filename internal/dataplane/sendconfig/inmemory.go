@@ -3,14 +3,24 @@ package sendconfig
 import (
 	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 )
 
 type ConfigService interface {
@@ -30,10 +40,24 @@ type ContentToDBLessConfigConverter interface {
 
 // UpdateStrategyInMemory implements the UpdateStrategy interface. It updates Kong's data-plane
 // configuration using its `POST /config` endpoint that is used by ConfigService.ReloadDeclarativeRawConfig.
+// The endpoint always replaces the whole declarative configuration (Kong doesn't support applying a partial
+// configuration in DB-less mode), hence the configuration is always sent in full. That applies to consumer
+// credentials as well: entity endpoints (e.g. `POST /consumers/{consumer}/key-auth`) are read-only in DB-less mode,
+// so a rotated credential can't be applied on its own. The cost of pushes can be reduced by skipping unchanged
+// configurations (see ConfigurationChangeDetector).
 type UpdateStrategyInMemory struct {
 	configService   ConfigService
 	configConverter ContentToDBLessConfigConverter
 	logger          logr.Logger
+	checkHash       bool
+	flattenErrors   bool
+	maxConfigBytes  int
+	sensitiveFields []string
+	verifier        StatusClient
+	conditionalPush *conditionalPush
+
+	externalEntitiesClient *kong.Client
+	externalEntityTags     []string
 }
 
 func NewUpdateStrategyInMemory(
@@ -45,26 +69,128 @@ func NewUpdateStrategyInMemory(
 		configService:   configService,
 		configConverter: configConverter,
 		logger:          logger,
+		checkHash:       true,
+		flattenErrors:   true,
 	}
 }
 
+// WithCheckHash returns a copy of the strategy that asks Kong to compare the configuration's hash with the currently
+// loaded one (and skip reloading it when they're equal) only when checkHash is true. It's true by default.
+func (s UpdateStrategyInMemory) WithCheckHash(checkHash bool) UpdateStrategyInMemory {
+	s.checkHash = checkHash
+	return s
+}
+
+// WithFlattenErrors returns a copy of the strategy that asks Kong to report configuration errors in the flattened
+// format (`flatten_errors` query parameter) only when flattenErrors is true. It's true by default, as flattened errors
+// let the controller attribute errors to particular Kubernetes resources (see InvalidConfigError and ResourceError).
+// Without them, a rejected configuration is reported only with Kong's sanitized error response body.
+func (s UpdateStrategyInMemory) WithFlattenErrors(flattenErrors bool) UpdateStrategyInMemory {
+	s.flattenErrors = flattenErrors
+	return s
+}
+
+// WithMaxConfigBytes returns a copy of the strategy that refuses to push configuration larger than maxConfigBytes
+// once serialized, failing with deckerrors.ConfigTooLargeError. Zero means no limit.
+func (s UpdateStrategyInMemory) WithMaxConfigBytes(maxConfigBytes int) UpdateStrategyInMemory {
+	s.maxConfigBytes = maxConfigBytes
+	return s
+}
+
+// WithSensitiveFields returns a copy of the strategy that redacts values of the given fields (on top of the well-known
+// sensitive fields, e.g. credentials' secrets) in /config error response bodies included in returned errors.
+func (s UpdateStrategyInMemory) WithSensitiveFields(fields []string) UpdateStrategyInMemory {
+	s.sensitiveFields = fields
+	return s
+}
+
+// WithVerification returns a copy of the strategy that, after every successful push, reads the configuration hash back
+// from Kong's status using statusClient and fails with deckerrors.ConfigVerificationError when it doesn't match
+// the hash of the pushed configuration (Kong reports the MD5 hash of the configuration it has loaded). It costs
+// an extra Admin API request per push, hence it's disabled (nil) by default.
+func (s UpdateStrategyInMemory) WithVerification(statusClient StatusClient) UpdateStrategyInMemory {
+	s.verifier = statusClient
+	return s
+}
+
 func (s UpdateStrategyInMemory) Update(ctx context.Context, targetState ContentWithHash) (
+	stats UpdateStats,
 	err error,
 	resourceErrors []ResourceError,
 	resourceErrorsParseErr error,
 ) {
+	if err := deckgen.ValidateUniqueIdentifiers(targetState.Content); err != nil {
+		return stats, deckerrors.ConfigConflictError{Err: err}, nil, nil
+	}
+	if err := s.checkExternalEntities(ctx, targetState.Content); err != nil {
+		return stats, err, nil, nil
+	}
+
+	preparationStart := time.Now()
 	dblessConfig := s.configConverter.Convert(targetState.Content)
 	config, err := json.Marshal(dblessConfig)
+	stats.PreparationDuration = time.Since(preparationStart)
 	if err != nil {
-		return fmt.Errorf("constructing kong configuration: %w", err), nil, nil
+		return stats, fmt.Errorf("constructing kong configuration: %w", err), nil, nil
+	}
+	if len(dblessConfig.RemovedPluginConfigNulls) > 0 {
+		// Nulls are expected to be generated only for plugins keeping them, so removing them may signal a bug.
+		s.logger.V(util.DebugLevel).Info("Removed null fields from plugin configs",
+			"removed_nulls_by_plugin", dblessConfig.RemovedPluginConfigNulls,
+		)
+		stats.RemovedPluginConfigNulls = lo.Sum(lo.Values(dblessConfig.RemovedPluginConfigNulls))
+	}
+	stats.PayloadSize = mo.Some(len(config))
+	if s.maxConfigBytes > 0 && len(config) > s.maxConfigBytes {
+		return stats, deckerrors.ConfigTooLargeError{Size: len(config), Limit: s.maxConfigBytes}, nil, nil
+	}
+
+	// The configuration's hash identifies it, so it's used as an idempotency key letting a proxy in front of Kong
+	// (or Kong itself) deduplicate pushes of the same configuration.
+	if len(targetState.Hash) > 0 {
+		ctx = adminapi.ContextWithIdempotencyKey(ctx, hex.EncodeToString(targetState.Hash))
 	}
 
-	if errBody, err := s.configService.ReloadDeclarativeRawConfig(ctx, bytes.NewReader(config), true, true); err != nil {
+	// The expected hash is set on the push only, not on the verification following it.
+	pushCtx, expectedHash := s.withExpectedHash(ctx)
+	pushCtx = adminapi.ContextWithResponseStatus(pushCtx)
+	errBody, err := s.configService.ReloadDeclarativeRawConfig(pushCtx, bytes.NewReader(config), s.checkHash, s.flattenErrors)
+	if err != nil {
+		s.untrackPushedHash()
+		if expectedHash != "" && adminapi.PreconditionFailedFromContext(pushCtx) {
+			return stats, deckerrors.ConfigPreconditionFailedError{Expected: expectedHash, Err: err}, nil, nil
+		}
 		resourceErrors, parseErr := parseFlatEntityErrors(errBody, s.logger)
-		return err, resourceErrors, parseErr
+		statusCode, _ := adminapi.ResponseStatusFromContext(pushCtx)
+		return stats, wrapConfigError(err, statusCode, errBody, s.sensitiveFields), resourceErrors, parseErr
 	}
 
-	return nil, nil, nil
+	if s.verifier == nil && s.conditionalPush == nil {
+		return stats, nil, nil, nil
+	}
+	configHash := md5.Sum(config) //nolint:gosec
+	pushedHash := hex.EncodeToString(configHash[:])
+	if s.verifier != nil {
+		if err := s.verify(ctx, pushedHash); err != nil {
+			s.untrackPushedHash()
+			return stats, err, nil, nil
+		}
+	}
+	s.trackPushedHash(pushedHash)
+
+	return stats, nil, nil, nil
+}
+
+// verify checks that the configuration hash reported by Kong matches the expected one.
+func (s UpdateStrategyInMemory) verify(ctx context.Context, expectedHash string) error {
+	status, err := s.verifier.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify pushed configuration: %w", err)
+	}
+	if status.ConfigurationHash != expectedHash {
+		return deckerrors.ConfigVerificationError{Expected: expectedHash, Actual: status.ConfigurationHash}
+	}
+	return nil
 }
 
 func (s UpdateStrategyInMemory) MetricsProtocol() metrics.Protocol {
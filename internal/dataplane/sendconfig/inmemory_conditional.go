@@ -0,0 +1,112 @@
+package sendconfig
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+)
+
+// ConfigHashTracker tracks hashes of configurations last pushed to DB-less Kong Gateways, letting
+// UpdateStrategyInMemory make pushes conditional on Kong still holding them (see WithConditionalPush).
+// A single ConfigHashTracker is meant to be shared by all the Kong instances.
+type ConfigHashTracker struct {
+	lock   sync.Mutex
+	hashes map[string]string // Configuration hashes keyed by data-plane URL.
+}
+
+// NewConfigHashTracker returns a ConfigHashTracker.
+func NewConfigHashTracker() *ConfigHashTracker {
+	return &ConfigHashTracker{
+		hashes: map[string]string{},
+	}
+}
+
+func (t *ConfigHashTracker) get(dataplane string) (string, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	hash, ok := t.hashes[dataplane]
+	return hash, ok
+}
+
+func (t *ConfigHashTracker) set(dataplane string, hash string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.hashes[dataplane] = hash
+}
+
+func (t *ConfigHashTracker) forget(dataplane string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.hashes, dataplane)
+}
+
+// conditionalPush configures pushes conditional on the configuration Kong holds.
+type conditionalPush struct {
+	tracker      *ConfigHashTracker
+	dataplane    string
+	statusClient StatusClient
+}
+
+// WithConditionalPush returns a copy of the strategy that sends the hash of the configuration the data-plane is
+// expected to hold in the If-Match header (see adminapi.ContextWithExpectedConfigHash).
+// Kong's POST /config doesn't evaluate the header, so pushes are effectively conditional only when the Admin API
+// is fronted by a proxy honoring it, which can then reject the push when the configuration has been modified by
+// another writer instead of silently overwriting it. Without such a proxy, the header is ignored.
+// The expected hash is the one of the configuration last pushed by the strategy (tracked by the tracker) or, when
+// unknown (e.g. on the first push or after a rejected one), the one read from Kong's status using statusClient,
+// which costs an extra Admin API request. A rejected push fails with deckerrors.ConfigPreconditionFailedError.
+func (s UpdateStrategyInMemory) WithConditionalPush(
+	tracker *ConfigHashTracker, dataplane string, statusClient StatusClient,
+) UpdateStrategyInMemory {
+	s.conditionalPush = &conditionalPush{
+		tracker:      tracker,
+		dataplane:    dataplane,
+		statusClient: statusClient,
+	}
+	return s
+}
+
+// expectedHash returns the hash of the configuration the data-plane is expected to hold.
+func (c *conditionalPush) expectedHash(ctx context.Context) (string, error) {
+	if hash, ok := c.tracker.get(c.dataplane); ok {
+		return hash, nil
+	}
+	status, err := c.statusClient.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	return status.ConfigurationHash, nil
+}
+
+// withExpectedHash returns a context making the push conditional on the data-plane holding the expected configuration.
+// When the expected hash can't be determined (or Kong doesn't report it), the push is made unconditionally.
+func (s UpdateStrategyInMemory) withExpectedHash(ctx context.Context) (context.Context, string) {
+	if s.conditionalPush == nil {
+		return ctx, ""
+	}
+	hash, err := s.conditionalPush.expectedHash(ctx)
+	if err != nil {
+		s.logger.Error(err, "Failed to determine configuration hash expected to be held by Kong, pushing unconditionally")
+		return ctx, ""
+	}
+	if hash == "" {
+		return ctx, ""
+	}
+	return adminapi.ContextWithExpectedConfigHash(ctx, hash), hash
+}
+
+// trackPushedHash tracks the hash of the configuration successfully pushed to the data-plane.
+func (s UpdateStrategyInMemory) trackPushedHash(hash string) {
+	if s.conditionalPush != nil {
+		s.conditionalPush.tracker.set(s.conditionalPush.dataplane, hash)
+	}
+}
+
+// untrackPushedHash forgets the hash tracked for the data-plane after a failed push, as it's unknown whether
+// (and which) configuration the data-plane holds. The next push reads it from Kong's status.
+func (s UpdateStrategyInMemory) untrackPushedHash() {
+	if s.conditionalPush != nil {
+		s.conditionalPush.tracker.forget(s.conditionalPush.dataplane)
+	}
+}
@@ -0,0 +1,120 @@
+package sendconfig_test
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+// conditionalDBLessKong is a DB-less Kong's Admin API fronted by a proxy rejecting configuration pushes whose
+// If-Match header doesn't match the hash of the configuration Kong holds (Kong itself doesn't evaluate it).
+type conditionalDBLessKong struct {
+	t    *testing.T
+	lock sync.Mutex
+
+	configurationHash string
+	statusRequests    int
+}
+
+func (k *conditionalDBLessKong) setConfigurationHash(hash string) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.configurationHash = hash
+}
+
+func (k *conditionalDBLessKong) statusRequestsCount() int {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	return k.statusRequests
+}
+
+func (k *conditionalDBLessKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/status":
+		k.statusRequests++
+		require.NoError(k.t, json.NewEncoder(w).Encode(map[string]any{"configuration_hash": k.configurationHash}))
+	case "/config":
+		if ifMatch := r.Header.Get(adminapi.HeaderNameIfMatch); ifMatch != "" && ifMatch != `"`+k.configurationHash+`"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"message": "configuration has changed"}`))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(k.t, err)
+		sum := md5.Sum(body) //nolint:gosec
+		k.configurationHash = hex.EncodeToString(sum[:])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestUpdateStrategyInMemory_ConditionalPush(t *testing.T) {
+	const initialHash = "00000000000000000000000000000000"
+	kongAdminAPI := &conditionalDBLessKong{t: t, configurationHash: initialHash}
+	server := httptest.NewServer(kongAdminAPI)
+	t.Cleanup(server.Close)
+	httpClient, err := adminapi.MakeHTTPClient(&adminapi.HTTPClientOpts{}, "")
+	require.NoError(t, err)
+	client, err := kong.NewClient(kong.String(server.URL), httpClient)
+	require.NoError(t, err)
+
+	tracker := sendconfig.NewConfigHashTracker()
+	strategy := sendconfig.NewUpdateStrategyInMemory(client, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+		WithConditionalPush(tracker, server.URL, client)
+	content := func(serviceName string) sendconfig.ContentWithHash {
+		return sendconfig.ContentWithHash{Content: &file.Content{
+			FormatVersion: "3.0",
+			Services:      []file.FService{{Service: kong.Service{Name: kong.String(serviceName)}}},
+		}}
+	}
+
+	t.Run("expected hash is read from Kong's status when unknown", func(t *testing.T) {
+		_, err, _, _ := strategy.Update(context.Background(), content("service-1"))
+		require.NoError(t, err)
+		require.Equal(t, 1, kongAdminAPI.statusRequestsCount())
+	})
+
+	t.Run("expected hash is the one of the configuration last pushed", func(t *testing.T) {
+		_, err, _, _ := strategy.Update(context.Background(), content("service-2"))
+		require.NoError(t, err)
+		require.Equal(t, 1, kongAdminAPI.statusRequestsCount(), "status shouldn't be read again")
+	})
+
+	t.Run("push is rejected when configuration was modified concurrently", func(t *testing.T) {
+		kongAdminAPI.setConfigurationHash("modified")
+		_, err, _, _ := strategy.Update(context.Background(), content("service-3"))
+		require.ErrorIs(t, err, deckerrors.ConfigPreconditionFailedError{})
+		var preconditionErr deckerrors.ConfigPreconditionFailedError
+		require.ErrorAs(t, err, &preconditionErr)
+		require.NotEqual(t, "modified", preconditionErr.Expected)
+		require.Equal(t, metrics.FailureReasonPreconditionFailed, metrics.PushFailureReason(err))
+	})
+
+	t.Run("expected hash is read from Kong's status again after a rejected push", func(t *testing.T) {
+		_, err, _, _ := strategy.Update(context.Background(), content("service-3"))
+		require.NoError(t, err)
+		require.Equal(t, 2, kongAdminAPI.statusRequestsCount())
+	})
+}
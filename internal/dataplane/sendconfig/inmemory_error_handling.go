@@ -3,11 +3,14 @@ package sendconfig
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/go-logr/logr"
+	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 )
 
@@ -77,6 +80,71 @@ type FlatError struct {
 	Type FlatErrorType `json:"type,omitempty" yaml:"type,omitempty"`
 }
 
+// InvalidConfigError is returned when Kong rejects configuration pushed to its /config endpoint reporting
+// errors of particular entities in the flattened errors format. It enumerates the failed entities along with
+// their field-level error messages.
+type InvalidConfigError struct {
+	Err      error
+	Entities []FlatEntityError
+}
+
+func (e InvalidConfigError) Error() string {
+	entities := make([]string, 0, len(e.Entities))
+	for _, entity := range e.Entities {
+		entities = append(entities, flatEntityErrorString(entity))
+	}
+	return fmt.Sprintf("%v: %s", e.Err, strings.Join(entities, "; "))
+}
+
+func (e InvalidConfigError) Unwrap() error {
+	return e.Err
+}
+
+// flatEntityErrorString describes an entity's errors, e.g. "route foo: methods: cannot set methods, paths[0]: invalid".
+func flatEntityErrorString(entity FlatEntityError) string {
+	identifier := entity.Name
+	if identifier == "" {
+		identifier = entity.ID
+	}
+	problems := make([]string, 0, len(entity.Errors))
+	for _, e := range entity.Errors {
+		if e.Type == FlatErrorTypeEntity || e.Field == "" {
+			if e.Message != "" {
+				problems = append(problems, e.Message)
+			}
+			continue
+		}
+		if e.Message != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", e.Field, e.Message))
+		}
+		for i, message := range e.Messages {
+			if message != "" {
+				problems = append(problems, fmt.Sprintf("%s[%d]: %s", e.Field, i, message))
+			}
+		}
+	}
+	return fmt.Sprintf("%s %s: %s", entity.Type, identifier, strings.Join(problems, ", "))
+}
+
+// wrapConfigError enriches an error returned from Kong's /config endpoint with details from its response.
+// Errors caused by error responses are wrapped in deckerrors.ConfigStatusError carrying the response's statusCode
+// (0 when no response has been received). It returns InvalidConfigError when the body contains flattened errors.
+// Otherwise (e.g. for older Kong versions that don't report flattened errors), the body sanitized with
+// sanitizedErrorBody is appended to the error message.
+func wrapConfigError(err error, statusCode int, body []byte, additionalSensitiveFields []string) error {
+	if statusCode >= http.StatusBadRequest {
+		err = deckerrors.ConfigStatusError{StatusCode: statusCode, Err: err}
+	}
+	var configError ConfigError
+	if jsonErr := json.Unmarshal(body, &configError); jsonErr == nil && len(configError.Flattened) > 0 {
+		return InvalidConfigError{Err: err, Entities: configError.Flattened}
+	}
+	if len(body) > 0 {
+		return fmt.Errorf("%w: %s", err, sanitizedErrorBody(body, additionalSensitiveFields))
+	}
+	return err
+}
+
 // parseFlatEntityErrors takes a Kong /config error response body and parses its "fields.flattened_errors" value
 // into errors associated with Kubernetes resources.
 func parseFlatEntityErrors(body []byte, logger logr.Logger) ([]ResourceError, error) {
@@ -173,3 +241,74 @@ func parseRawResourceError(raw rawResourceError) (ResourceError, error) {
 	}
 	return re, nil
 }
+
+const (
+	// maxErrorBodyLength is the maximum length of a /config error response body included in returned errors.
+	maxErrorBodyLength = 1024
+
+	redactedValue = "REDACTED"
+)
+
+// sensitiveErrorBodyFields are names of fields whose values are redacted from /config error response bodies
+// included in returned errors as they may contain credentials. Additional fields (e.g. secrets of custom plugins)
+// can be configured with Config.SensitiveFields.
+var sensitiveErrorBodyFields = map[string]struct{}{
+	"key":            {},
+	"secret":         {},
+	"password":       {},
+	"client_secret":  {},
+	"private_key":    {},
+	"rsa_public_key": {},
+}
+
+// sanitizedErrorBody returns a /config error response body with values of sensitive fields (sensitiveErrorBodyFields
+// and additionalSensitiveFields) redacted, truncated to maxErrorBodyLength, so it's safe to be included in errors
+// that get logged.
+func sanitizedErrorBody(body []byte, additionalSensitiveFields []string) string {
+	isSensitive := sensitiveFieldMatcher(additionalSensitiveFields)
+
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		if redacted, err := json.Marshal(redactSensitiveFields(parsed, isSensitive)); err == nil {
+			body = redacted
+		}
+	}
+
+	if len(body) > maxErrorBodyLength {
+		return string(body[:maxErrorBodyLength]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// sensitiveFieldMatcher returns a function telling whether a field is sensitive, i.e. it's one of the well-known
+// sensitiveErrorBodyFields or additionalSensitiveFields.
+func sensitiveFieldMatcher(additionalSensitiveFields []string) func(field string) bool {
+	return func(field string) bool {
+		_, ok := sensitiveErrorBodyFields[field]
+		return ok || lo.Contains(additionalSensitiveFields, field)
+	}
+}
+
+// redactSensitiveFields recursively replaces values of sensitive fields in a JSON value.
+func redactSensitiveFields(v any, isSensitive func(field string) bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for field, value := range v {
+			if isSensitive(field) {
+				if _, isString := value.(string); isString {
+					v[field] = redactedValue
+					continue
+				}
+			}
+			v[field] = redactSensitiveFields(value, isSensitive)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = redactSensitiveFields(value, isSensitive)
+		}
+		return v
+	default:
+		return v
+	}
+}
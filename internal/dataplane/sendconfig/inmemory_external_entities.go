@@ -0,0 +1,122 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+)
+
+// ExternalEntitiesError is returned from UpdateStrategyInMemory.Update when pushing the configuration would remove
+// entities managed by other processes than the controller (see Config.ExternalEntityTags) from Kong.
+type ExternalEntitiesError struct {
+	// Tags are tags of external entities loaded in Kong that are missing from the configuration.
+	Tags []string
+}
+
+func (e ExternalEntitiesError) Error() string {
+	return fmt.Sprintf(
+		"refusing to replace DB-less configuration: Kong holds external entities tagged with %s "+
+			"that are missing from the configuration and would be removed",
+		strings.Join(e.Tags, ", "),
+	)
+}
+
+// WithExternalEntityTags returns a copy of the strategy that, before every push, checks (using client) whether Kong
+// holds entities tagged with any of tags, failing with ExternalEntitiesError when the configuration doesn't carry any
+// entity tagged with the same tag (i.e. the external entities haven't been merged into it). Kong doesn't support
+// merging configuration by tags in DB-less mode, hence pushing it would remove them.
+func (s UpdateStrategyInMemory) WithExternalEntityTags(client *kong.Client, tags []string) UpdateStrategyInMemory {
+	s.externalEntitiesClient = client
+	s.externalEntityTags = tags
+	return s
+}
+
+// checkExternalEntities returns ExternalEntitiesError if pushing the content would remove external entities.
+func (s UpdateStrategyInMemory) checkExternalEntities(ctx context.Context, content *file.Content) error {
+	if s.externalEntitiesClient == nil || len(s.externalEntityTags) == 0 {
+		return nil
+	}
+
+	contentTags := contentEntityTags(content)
+	var missingTags []string
+	for _, tag := range s.externalEntityTags {
+		if lo.Contains(contentTags, tag) {
+			continue
+		}
+		exists, err := taggedEntitiesExist(ctx, s.externalEntitiesClient, tag)
+		if err != nil {
+			return fmt.Errorf("failed to check external entities tagged with %s: %w", tag, err)
+		}
+		if exists {
+			missingTags = append(missingTags, tag)
+		}
+	}
+	if len(missingTags) > 0 {
+		return ExternalEntitiesError{Tags: missingTags}
+	}
+	return nil
+}
+
+// taggedEntitiesExist tells whether Kong holds any entity tagged with the tag.
+func taggedEntitiesExist(ctx context.Context, client *kong.Client, tag string) (bool, error) {
+	req, err := client.NewRequest(http.MethodGet, "/tags/"+url.PathEscape(tag), struct {
+		Size int `url:"size"`
+	}{Size: 1}, nil)
+	if err != nil {
+		return false, err
+	}
+	var resp struct {
+		Data []any `json:"data"`
+	}
+	if _, err := client.Do(ctx, req, &resp); err != nil {
+		return false, err
+	}
+	return len(resp.Data) > 0, nil
+}
+
+// contentEntityTags returns tags of the content's entities.
+func contentEntityTags(content *file.Content) []string {
+	var tags []*string
+	addPlugins := func(plugins []*file.FPlugin) {
+		for _, p := range plugins {
+			tags = append(tags, p.Tags...)
+		}
+	}
+	addRoutes := func(routes []*file.FRoute) {
+		for _, r := range routes {
+			tags = append(tags, r.Tags...)
+			addPlugins(r.Plugins)
+		}
+	}
+
+	for _, s := range content.Services {
+		tags = append(tags, s.Tags...)
+		addRoutes(s.Routes)
+		addPlugins(s.Plugins)
+	}
+	addRoutes(lo.ToSlicePtr(content.Routes))
+	addPlugins(lo.ToSlicePtr(content.Plugins))
+	for _, c := range content.Consumers {
+		tags = append(tags, c.Tags...)
+		addPlugins(c.Plugins)
+	}
+	for _, u := range content.Upstreams {
+		tags = append(tags, u.Tags...)
+		for _, t := range u.Targets {
+			tags = append(tags, t.Tags...)
+		}
+	}
+	for _, c := range content.Certificates {
+		tags = append(tags, c.Tags...)
+	}
+	for _, c := range content.CACertificates {
+		tags = append(tags, c.Tags...)
+	}
+	return lo.Uniq(lo.Map(tags, func(t *string, _ int) string { return lo.FromPtr(t) }))
+}
@@ -0,0 +1,75 @@
+package sendconfig_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+func TestUpdateStrategyInMemory_ExternalEntityTags(t *testing.T) {
+	// Kong holds an entity tagged with "external".
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.TrimPrefix(r.URL.Path, "/tags/") == "external" {
+			_, _ = w.Write([]byte(`{"data":[{"entity_name":"services","entity_id":"1","tag":"external"}],"next":null}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[],"next":null}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name               string
+		externalEntityTags []string
+		contentTags        []string
+		expectedErrorTags  []string
+	}{
+		{
+			name:               "no external entities in kong",
+			externalEntityTags: []string{"other"},
+		},
+		{
+			name:               "external entities in kong missing from the configuration",
+			externalEntityTags: []string{"other", "external"},
+			expectedErrorTags:  []string{"external"},
+		},
+		{
+			name:               "external entities merged into the configuration",
+			externalEntityTags: []string{"external"},
+			contentTags:        []string{"external"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			content := testContent()
+			content.Services[0].Tags = kong.StringSlice(tc.contentTags...)
+			configService := &recordingConfigService{}
+			strategy := sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+				WithExternalEntityTags(client, tc.externalEntityTags)
+
+			_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: content})
+			if tc.expectedErrorTags == nil {
+				require.NoError(t, err)
+				require.NotNil(t, configService.config, "config should be sent")
+				return
+			}
+			var externalEntitiesErr sendconfig.ExternalEntitiesError
+			require.True(t, errors.As(err, &externalEntitiesErr))
+			require.Equal(t, tc.expectedErrorTags, externalEntitiesErr.Tags)
+			require.Nil(t, configService.config, "config should not be sent")
+		})
+	}
+}
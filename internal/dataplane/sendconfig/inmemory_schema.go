@@ -2,6 +2,8 @@ package sendconfig
 
 import (
 	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 )
 
 // DBLessConfig is the configuration that is sent to Kong's data-plane via its `POST /config` endpoint after being
@@ -10,6 +12,10 @@ import (
 type DBLessConfig struct {
 	file.Content
 	ConsumerGroupConsumerRelationships []ConsumerGroupConsumerRelationship `json:"consumer_group_consumers,omitempty"`
+
+	// RemovedPluginConfigNulls are numbers of null fields removed from plugins' configs during the conversion keyed
+	// by plugin name. It's not a part of the configuration sent to Kong.
+	RemovedPluginConfigNulls map[string]int `json:"-"`
 }
 
 // ConsumerGroupConsumerRelationship is a relationship between a ConsumerGroup and a Consumer.
@@ -18,9 +24,14 @@ type ConsumerGroupConsumerRelationship struct {
 	Consumer      string `json:"consumer"`
 }
 
-type DefaultContentToDBLessConfigConverter struct{}
+type DefaultContentToDBLessConfigConverter struct {
+	// PluginsKeepingNulls are names of plugins whose configs are exempt from removing null values. It's meant
+	// for (custom) plugins for which an explicit null is meaningful. Such plugins must accept nulls in their configs
+	// on the target Kong version, otherwise Kong will reject the whole configuration.
+	PluginsKeepingNulls []string
+}
 
-func (DefaultContentToDBLessConfigConverter) Convert(content *file.Content) DBLessConfig {
+func (c DefaultContentToDBLessConfigConverter) Convert(content *file.Content) DBLessConfig {
 	dblessConfig := DBLessConfig{
 		Content: *content,
 	}
@@ -29,7 +40,7 @@ func (DefaultContentToDBLessConfigConverter) Convert(content *file.Content) DBLe
 	dblessConfig.Content.Info = nil
 
 	// DBLess schema does not support nulls in plugin configs.
-	cleanUpNullsInPluginConfigs(&dblessConfig.Content)
+	dblessConfig.RemovedPluginConfigNulls = cleanUpNullsInPluginConfigs(&dblessConfig.Content, c.PluginsKeepingNulls)
 
 	// DBLess schema does not 1-1 match decK's schema for ConsumerGroups.
 	convertConsumerGroups(&dblessConfig)
@@ -37,44 +48,48 @@ func (DefaultContentToDBLessConfigConverter) Convert(content *file.Content) DBLe
 	return dblessConfig
 }
 
-// cleanUpNullsInPluginConfigs removes null values from plugins' configs.
-func cleanUpNullsInPluginConfigs(state *file.Content) {
-	for _, s := range state.Services {
-		for _, p := range s.Plugins {
-			for k, v := range p.Config {
-				if v == nil {
-					delete(p.Config, k)
+// cleanUpNullsInPluginConfigs removes null values from plugins' configs, except for configs of plugins
+// named in pluginsKeepingNulls. It returns numbers of removed null values keyed by plugin name (nil if none
+// were removed).
+func cleanUpNullsInPluginConfigs(state *file.Content, pluginsKeepingNulls []string) map[string]int {
+	var removed map[string]int
+	cleanUp := func(p *kong.Plugin) {
+		if p.Name != nil && lo.Contains(pluginsKeepingNulls, *p.Name) {
+			return
+		}
+		for k, v := range p.Config {
+			if v == nil {
+				delete(p.Config, k)
+				if removed == nil {
+					removed = map[string]int{}
 				}
+				removed[lo.FromPtr(p.Name)]++
 			}
 		}
+	}
+
+	for _, s := range state.Services {
+		for _, p := range s.Plugins {
+			cleanUp(&p.Plugin)
+		}
 		for _, r := range state.Routes {
 			for _, p := range r.Plugins {
-				for k, v := range p.Config {
-					if v == nil {
-						delete(p.Config, k)
-					}
-				}
+				cleanUp(&p.Plugin)
 			}
 		}
 	}
 
 	for _, c := range state.Consumers {
 		for _, p := range c.Plugins {
-			for k, v := range p.Config {
-				if v == nil {
-					delete(p.Config, k)
-				}
-			}
+			cleanUp(&p.Plugin)
 		}
 	}
 
-	for _, p := range state.Plugins {
-		for k, v := range p.Config {
-			if v == nil {
-				delete(p.Config, k)
-			}
-		}
+	for i := range state.Plugins {
+		cleanUp(&state.Plugins[i].Plugin)
 	}
+
+	return removed
 }
 
 // convertConsumerGroups drops consumer groups related fields that are not supported in DBLess schema:
@@ -336,6 +336,7 @@ func TestDefaultContentToDBLessConfigConverter(t *testing.T) {
 						},
 					},
 				},
+				RemovedPluginConfigNulls: map[string]int{"p1": 4},
 			},
 		},
 	}
@@ -348,6 +349,50 @@ func TestDefaultContentToDBLessConfigConverter(t *testing.T) {
 	}
 }
 
+func TestDefaultContentToDBLessConfigConverter_PluginsKeepingNulls(t *testing.T) {
+	content := &file.Content{
+		Plugins: []file.FPlugin{
+			{
+				Plugin: kong.Plugin{
+					Name:   kong.String("custom-plugin"),
+					Config: kong.Configuration{"key": nil, "other": "value"},
+				},
+			},
+			{
+				Plugin: kong.Plugin{
+					Name:   kong.String("rate-limiting"),
+					Config: kong.Configuration{"key": nil, "other": "value"},
+				},
+			},
+		},
+		Services: []file.FService{
+			{
+				Service: kong.Service{Name: kong.String("service")},
+				Plugins: []*file.FPlugin{
+					{
+						Plugin: kong.Plugin{
+							Name:   kong.String("custom-plugin"),
+							Config: kong.Configuration{"key": nil},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	converter := sendconfig.DefaultContentToDBLessConfigConverter{PluginsKeepingNulls: []string{"custom-plugin"}}
+	dblessConfig := converter.Convert(content)
+
+	require.Equal(t, kong.Configuration{"key": nil, "other": "value"}, dblessConfig.Plugins[0].Config,
+		"nulls should be kept for an exempted plugin")
+	require.Equal(t, kong.Configuration{"other": "value"}, dblessConfig.Plugins[1].Config,
+		"nulls should be removed for other plugins")
+	require.Equal(t, kong.Configuration{"key": nil}, dblessConfig.Services[0].Plugins[0].Config,
+		"nulls should be kept for an exempted service plugin")
+	require.Equal(t, map[string]int{"rate-limiting": 1}, dblessConfig.RemovedPluginConfigNulls,
+		"only nulls removed from other plugins should be reported")
+}
+
 func BenchmarkDefaultContentToDBLessConfigConverter_Convert(b *testing.B) {
 	content := &file.Content{
 		Info: &file.Info{
@@ -0,0 +1,344 @@
+package sendconfig_test
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+// recordingConfigService is a ConfigService recording parameters it was called with.
+type recordingConfigService struct {
+	config         []byte
+	checkHash      bool
+	flattenErrors  bool
+	idempotencyKey string
+}
+
+func (s *recordingConfigService) ReloadDeclarativeRawConfig(
+	ctx context.Context,
+	config io.Reader,
+	checkHash bool,
+	flattenErrors bool,
+) ([]byte, error) {
+	b, err := io.ReadAll(config)
+	if err != nil {
+		return nil, err
+	}
+	s.config = b
+	s.checkHash = checkHash
+	s.flattenErrors = flattenErrors
+	s.idempotencyKey, _ = adminapi.IdempotencyKeyFromContext(ctx)
+	return nil, nil
+}
+
+func TestUpdateStrategyInMemory_CheckHash(t *testing.T) {
+	testCases := []struct {
+		name              string
+		strategy          func(sendconfig.ConfigService) sendconfig.UpdateStrategyInMemory
+		expectedCheckHash bool
+	}{
+		{
+			name: "check hash is enabled by default",
+			strategy: func(configService sendconfig.ConfigService) sendconfig.UpdateStrategyInMemory {
+				return sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+			},
+			expectedCheckHash: true,
+		},
+		{
+			name: "check hash can be disabled",
+			strategy: func(configService sendconfig.ConfigService) sendconfig.UpdateStrategyInMemory {
+				return sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+					WithCheckHash(false)
+			},
+			expectedCheckHash: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			configService := &recordingConfigService{}
+			_, err, _, _ := tc.strategy(configService).Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCheckHash, configService.checkHash)
+			require.True(t, configService.flattenErrors)
+		})
+	}
+}
+
+func TestUpdateStrategyInMemory_FlattenErrors(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		strategy              func(sendconfig.ConfigService) sendconfig.UpdateStrategyInMemory
+		expectedFlattenErrors bool
+	}{
+		{
+			name: "flatten errors is enabled by default",
+			strategy: func(configService sendconfig.ConfigService) sendconfig.UpdateStrategyInMemory {
+				return sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+			},
+			expectedFlattenErrors: true,
+		},
+		{
+			name: "flatten errors can be disabled",
+			strategy: func(configService sendconfig.ConfigService) sendconfig.UpdateStrategyInMemory {
+				return sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+					WithFlattenErrors(false)
+			},
+			expectedFlattenErrors: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			configService := &recordingConfigService{}
+			_, err, _, _ := tc.strategy(configService).Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFlattenErrors, configService.flattenErrors)
+		})
+	}
+}
+
+func TestUpdateStrategyInMemory_IdempotencyKey(t *testing.T) {
+	t.Run("hash is used as idempotency key", func(t *testing.T) {
+		configService := &recordingConfigService{}
+		strategy := sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+
+		_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{
+			Content: testContent(),
+			Hash:    []byte{0xde, 0xad, 0xbe, 0xef},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "deadbeef", configService.idempotencyKey)
+	})
+
+	t.Run("no idempotency key without hash", func(t *testing.T) {
+		configService := &recordingConfigService{}
+		strategy := sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+
+		_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+		require.NoError(t, err)
+		require.Empty(t, configService.idempotencyKey)
+	})
+}
+
+func TestUpdateStrategyInMemory_RemovedPluginConfigNulls(t *testing.T) {
+	strategy := sendconfig.NewUpdateStrategyInMemory(&recordingConfigService{}, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+
+	t.Run("no nulls removed", func(t *testing.T) {
+		stats, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+		require.NoError(t, err)
+		require.Zero(t, stats.RemovedPluginConfigNulls)
+	})
+
+	t.Run("nulls removed", func(t *testing.T) {
+		content := testContent()
+		content.Plugins = []file.FPlugin{
+			{Plugin: kong.Plugin{Name: kong.String("p1"), Config: kong.Configuration{"a": nil, "b": nil, "c": "value"}}},
+		}
+		stats, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: content})
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.RemovedPluginConfigNulls)
+	})
+}
+
+func TestUpdateStrategyInMemory_MaxConfigBytes(t *testing.T) {
+	converter := sendconfig.DefaultContentToDBLessConfigConverter{}
+	serialized, err := json.Marshal(converter.Convert(testContent()))
+	require.NoError(t, err)
+	size := len(serialized)
+
+	t.Run("config within the limit is sent", func(t *testing.T) {
+		configService := &recordingConfigService{}
+		strategy := sendconfig.NewUpdateStrategyInMemory(configService, converter, logr.Discard()).WithMaxConfigBytes(size)
+
+		_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+		require.NoError(t, err)
+		require.Len(t, configService.config, size)
+	})
+
+	t.Run("config exceeding the limit is not sent", func(t *testing.T) {
+		configService := &recordingConfigService{}
+		strategy := sendconfig.NewUpdateStrategyInMemory(configService, converter, logr.Discard()).WithMaxConfigBytes(size - 1)
+
+		_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+		var tooLargeErr deckerrors.ConfigTooLargeError
+		require.ErrorAs(t, err, &tooLargeErr)
+		require.Equal(t, size, tooLargeErr.Size)
+		require.Equal(t, size-1, tooLargeErr.Limit)
+		require.Nil(t, configService.config)
+	})
+}
+
+// failingConfigService is a ConfigService failing with a predefined error response body.
+type failingConfigService struct {
+	body []byte
+}
+
+func (s failingConfigService) ReloadDeclarativeRawConfig(context.Context, io.Reader, bool, bool) ([]byte, error) {
+	return s.body, errors.New("invalid configuration")
+}
+
+func TestUpdateStrategyInMemory_SensitiveFields(t *testing.T) {
+	configService := failingConfigService{
+		body: []byte(`{"fields":{"plugins":[{"config":{"api_token":"s3cr3t","password":"p4ssw0rd"}}]}}`),
+	}
+	strategy := sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+		WithSensitiveFields([]string{"api_token"})
+
+	_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "s3cr3t")
+	require.NotContains(t, err.Error(), "p4ssw0rd")
+}
+
+func TestUpdateStrategyInMemory_DuplicateIdentifiers(t *testing.T) {
+	content := testContent()
+	content.Services = append(content.Services, content.Services[0])
+	configService := &recordingConfigService{}
+	strategy := sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+
+	_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: content})
+	require.ErrorIs(t, err, deckerrors.ConfigConflictError{})
+	require.ErrorContains(t, err, "duplicate service names: service")
+	require.Nil(t, configService.config, "config should not be sent")
+}
+
+// statusClientFunc is a StatusClient calling the function.
+type statusClientFunc func(context.Context) (*kong.Status, error)
+
+func (f statusClientFunc) Status(ctx context.Context) (*kong.Status, error) {
+	return f(ctx)
+}
+
+func TestUpdateStrategyInMemory_Verification(t *testing.T) {
+	// loadedConfigHash returns a status reporting the MD5 hash of the configuration the config service received.
+	loadedConfigHash := func(configService *recordingConfigService) statusClientFunc {
+		return func(context.Context) (*kong.Status, error) {
+			sum := md5.Sum(configService.config) //nolint:gosec
+			return &kong.Status{ConfigurationHash: hex.EncodeToString(sum[:])}, nil
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		statusClient  func(*recordingConfigService) sendconfig.StatusClient
+		expectedError func(t *testing.T, err error)
+	}{
+		{
+			name: "hash reported by kong matches the pushed configuration",
+			statusClient: func(configService *recordingConfigService) sendconfig.StatusClient {
+				return loadedConfigHash(configService)
+			},
+			expectedError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "hash reported by kong doesn't match the pushed configuration",
+			statusClient: func(*recordingConfigService) sendconfig.StatusClient {
+				return statusClientMock{expectedValue: &kong.Status{ConfigurationHash: "other"}}
+			},
+			expectedError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, deckerrors.ConfigVerificationError{})
+				var verificationErr deckerrors.ConfigVerificationError
+				require.True(t, errors.As(err, &verificationErr))
+				require.Equal(t, "other", verificationErr.Actual)
+			},
+		},
+		{
+			name: "status can't be read",
+			statusClient: func(*recordingConfigService) sendconfig.StatusClient {
+				return statusClientMock{expectedError: errors.New("status error")}
+			},
+			expectedError: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "status error")
+				require.NotErrorIs(t, err, deckerrors.ConfigVerificationError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			configService := &recordingConfigService{}
+			strategy := sendconfig.NewUpdateStrategyInMemory(configService, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+				WithVerification(tc.statusClient(configService))
+			_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+			tc.expectedError(t, err)
+		})
+	}
+}
+
+// newConfigRejectingKong returns a client of an Admin API responding to configuration pushes with statusCode.
+func newConfigRejectingKong(t *testing.T, statusCode int) *kong.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"message": %q}`, http.StatusText(statusCode))))
+	}))
+	t.Cleanup(server.Close)
+	client, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+	return client.AdminAPIClient()
+}
+
+func TestUpdateStrategyInMemory_ErrorResponseStatus(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		statusCode            int
+		expectedFailureReason string
+	}{
+		{
+			name:                  "bad request",
+			statusCode:            http.StatusBadRequest,
+			expectedFailureReason: metrics.FailureReasonValidation,
+		},
+		{
+			name:                  "unauthorized",
+			statusCode:            http.StatusUnauthorized,
+			expectedFailureReason: metrics.FailureReasonAuth,
+		},
+		{
+			name:                  "forbidden",
+			statusCode:            http.StatusForbidden,
+			expectedFailureReason: metrics.FailureReasonAuth,
+		},
+		{
+			name:                  "service unavailable",
+			statusCode:            http.StatusServiceUnavailable,
+			expectedFailureReason: metrics.FailureReasonUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy := sendconfig.NewUpdateStrategyInMemory(
+				newConfigRejectingKong(t, tc.statusCode), sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard(),
+			)
+
+			_, err, _, _ := strategy.Update(context.Background(), sendconfig.ContentWithHash{Content: testContent()})
+			require.Error(t, err)
+			require.Equal(t, []int{tc.statusCode}, deckerrors.StatusCodes(err))
+			require.Equal(t, tc.expectedFailureReason, metrics.PushFailureReason(err))
+		})
+	}
+}
@@ -3,30 +3,73 @@ package sendconfig
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
 )
 
+// ContentTransformFunc transforms the configuration in place before it's pushed.
+type ContentTransformFunc func(content *file.Content) error
+
+// OnAppliedFunc is a callback invoked after a configuration was successfully pushed to a data-plane. newSHA is the SHA
+// of the pushed configuration and changed tells whether it differed from the configuration pushed previously.
+type OnAppliedFunc func(newSHA []byte, changed bool)
+
 // Config gathers parameters that are needed for sending configuration to Kong Admin APIs.
 type Config struct {
 	// Currently, this assumes that all underlying clients are using the same version
 	// hence this shared field in here.
 	Version semver.Version
 
+	// OnKongVersionMismatch defines what PerformUpdate does when a Kong Gateway instance reports a version diverging
+	// from Version by a major version (e.g. when Kong was upgraded, but the controller still generates configuration
+	// for the old version). The check is disabled by default. It's not relevant for Konnect client.
+	OnKongVersionMismatch KongVersionMismatchAction
+
 	// InMemory tells whether a Kong Gateway Admin APIs should be communicated in DB-less mode.
 	// It's not relevant for Konnect client.
 	InMemory bool
 
+	// GatewayQuorum is the minimum number of Kong Gateway instances that must successfully apply the configuration
+	// for the update to be considered successful when it's fanned out to multiple instances in DB-less mode.
+	// Failures of the remaining instances are then only logged. Zero (the default) requires all of them to succeed.
+	GatewayQuorum int
+
+	// DBModeChecker, when set, is used to verify that Kong Gateway instances run in the DB mode matching InMemory
+	// before configuration is pushed to them, failing the push with DBModeMismatchError otherwise. Every instance
+	// is probed only once. It's not relevant for Konnect client.
+	DBModeChecker *DBModeChecker
+
 	// Concurrency defines how many concurrent goroutines should be used when syncing configuration in DB-mode.
 	Concurrency int
 
-	// FilterTags are tags used to manage and filter entities in Kong.
+	// AutoConcurrency, when set, makes the concurrency of syncing configuration in DB-mode derived from the number
+	// of entities in the configuration instead of using the static Concurrency.
+	AutoConcurrency *AutoConcurrencyPolicy
+
+	// DeckSyncerOptions tune decK's syncer used for syncing configuration in DB mode.
+	DeckSyncerOptions DeckSyncerOptions
+
+	// RollbackOnFailure makes configuration updates in DB mode roll Kong back to the configuration it held before
+	// the update when syncing some of the entities fails. See UpdateStrategyDBMode.WithRollback for details.
+	RollbackOnFailure bool
+
+	// FilterTags are tags used to manage and filter entities in Kong. They scope what the controller manages only
+	// in DB mode: in DB-less mode, Kong's `POST /config` replaces the whole configuration (it doesn't support merging
+	// it by tags), so entities not generated by the controller are removed. See ExternalEntityTags.
 	FilterTags []string
 
+	// ExternalEntityTags, when set, guard entities tagged with them (e.g. injected into Kong by other processes)
+	// against being removed by DB-less configuration pushes. A push fails with ExternalEntitiesError when Kong holds
+	// such entities, unless the configuration carries entities with the same tags (e.g. merged into it with
+	// ContentTransformers). Checking it costs an extra Admin API request per tag and push. It's ignored in DB mode.
+	ExternalEntityTags []string
+
 	// SkipCACertificates disables CA certificates, to avoid fighting over configuration in multi-workspace
 	// environments. See https://github.com/Kong/deck/pull/617
 	SkipCACertificates bool
@@ -35,8 +78,191 @@ type Config struct {
 	// updates to the data-plane.
 	EnableReverseSync bool
 
+	// ReverseSyncEntityTypes are types of entities for which reverse sync is enabled, i.e. entities of which are
+	// synced even if the configuration SHA has not changed since the previous update (e.g. to revert changes made
+	// to them directly through the Admin API). Entities of other types are synced only on configuration change.
+	// It's supported only in DB mode.
+	ReverseSyncEntityTypes []EntityType
+
+	// IgnoredEntityTypes are types of entities that the controller never touches in DB mode: entities of them are
+	// neither created, updated nor deleted, regardless of the generated configuration and the selector tags. It's
+	// meant for entities managed by other means (e.g. vaults or certificates provisioned by another tool).
+	IgnoredEntityTypes []EntityType
+
 	// ExpressionRoutes indicates whether to use Kong's expression routes.
 	ExpressionRoutes bool
+
+	// DisableCheckHash makes configuration pushes in DB-less mode not ask Kong to compare the configuration's
+	// hash with the currently loaded one (`check_hash` query parameter). As a result, Kong always fully reloads its
+	// configuration, even if it hasn't changed, which is costly for large configurations. It's meant only for
+	// Kong versions that misbehave when the configuration hash check is requested.
+	DisableCheckHash bool
+
+	// DisableFlattenErrors makes configuration pushes in DB-less mode not ask Kong to report errors in the flattened
+	// format (`flatten_errors` query parameter). Without flattened errors, configuration errors can't be attributed
+	// to particular Kubernetes resources. It's meant only for Kong versions that don't support the parameter.
+	DisableFlattenErrors bool
+
+	// InMemoryPluginsKeepingNulls are names of plugins whose configs keep their null values in DB-less mode,
+	// where nulls are otherwise removed from plugin configs as Kong rejects them. The listed plugins must
+	// accept nulls in their configs on the target Kong version.
+	InMemoryPluginsKeepingNulls []string
+
+	// Fingerprinter, when set, is used instead of deckgen.GenerateSHA to compute the SHA (fingerprint) of
+	// the configuration that is compared with the one of the configuration last applied to decide whether it has
+	// to be pushed, and that is reported in logs and metrics. It must be deterministic and return a non-empty result.
+	// It does not affect the detection of Kong instances having no configuration, as that relies on the hash
+	// computed by Kong itself (see DefaultConfigurationChangeDetector.WithInitialHashes).
+	Fingerprinter func(*file.Content) ([]byte, error)
+
+	// SkipStatusCheckOnEqualSHA makes PerformUpdate trust the equality of the last pushed and the current
+	// configuration SHAs and skip querying the Admin API's status endpoint for the configuration hash.
+	// It should be enabled only for Kong versions known to reliably report their configuration hash.
+	// When enabled, Kong instances restarted with no configuration will not be detected by the controller,
+	// so their readiness must be detected another way (e.g. by recreating the Admin API client on restart).
+	SkipStatusCheckOnEqualSHA bool
+
+	// NoConfigChangeLogThrottler limits how often the log line about skipping a push of an unchanged configuration
+	// is emitted for a data-plane. When nil, it's emitted on every skipped push.
+	NoConfigChangeLogThrottler *SHALogThrottler
+
+	// DumpLimiter, when set, limits the number of concurrent dumps of Kong instances' current state in DB mode.
+	DumpLimiter *DumpLimiter
+
+	// ChangeEvents, when set, receives a ChangeEvent for every entity change applied in DB mode (e.g. to stream
+	// configuration changes to an audit system). Events are dropped rather than stalling pushes when it's full.
+	ChangeEvents *ChangeEventStream
+
+	// DetectDrift makes PerformUpdate check whether the configuration of a data-plane diverged from the one last
+	// applied to it (e.g. due to changes made directly through the Admin API) when the configuration has not changed,
+	// reporting the drift with a metric and a log line. It costs an extra dump of the data-plane's current state
+	// on every update. It's supported only in DB mode and is ignored for Kong Gateways in DB-less mode.
+	DetectDrift bool
+
+	// DryRun makes PerformUpdate only compute changes that would be made to the data-plane's configuration,
+	// without applying them. Both DB-less and DB-backed data-planes are diffed with decK against their current state
+	// fetched from the Admin API. For DB-less data-planes the diff is entity-level, whereas the actual push replaces
+	// the whole configuration at once, so it only approximates the change.
+	DryRun bool
+
+	// PushTimeout is the maximum time a single configuration push (including its retries) may take.
+	// It's applied on top of the deadline of the context passed to PerformUpdate. Zero means no push-specific timeout.
+	PushTimeout time.Duration
+
+	// AdaptivePushTimeout, when set, computes the push timeout from durations of recent pushes to the data-plane
+	// instead of using the fixed PushTimeout, so that pushes to a data-plane that has become slower don't time out
+	// while stuck pushes are still cut off.
+	AdaptivePushTimeout *AdaptivePushTimeout
+
+	// SlowPushThreshold, when set, makes a warning logged for every configuration push (including its retries)
+	// taking longer than it, giving an early signal of the Admin API's degradation before pushes start timing out.
+	SlowPushThreshold time.Duration
+
+	// SlowPushLogThrottler limits how often the slow push warning (see SlowPushThreshold) is emitted for a data-plane
+	// while its pushes are persistently slow, so that a degraded Admin API doesn't flood the logs. When nil, it's
+	// emitted for every slow push.
+	SlowPushLogThrottler *DecayingLogThrottler
+
+	// MaxConfigBytes, when set, makes pushes of configuration larger than it (once serialized, or in DB mode,
+	// estimated by the size of the serialized target configuration) to Kong Gateways fail early with
+	// deckerrors.ConfigTooLargeError, protecting them from running out of memory due to a runaway configuration.
+	MaxConfigBytes int
+
+	// VerifyInMemoryPushes makes every successful configuration push to a DB-less Kong Gateway followed by reading
+	// its configuration hash back and failing the push with deckerrors.ConfigVerificationError when it doesn't match
+	// the pushed configuration. It costs an extra Admin API request per push.
+	VerifyInMemoryPushes bool
+
+	// ConfigHashTracker, when set, makes configuration pushes to DB-less Kong Gateways conditional on Kong holding
+	// the configuration last pushed to it (see UpdateStrategyInMemory.WithConditionalPush), so that a configuration
+	// modified concurrently by another writer makes the push fail with deckerrors.ConfigPreconditionFailedError
+	// instead of being silently overwritten. Kong itself doesn't enforce the condition, it's only enforced by a proxy
+	// in front of the Admin API honoring the If-Match header.
+	ConfigHashTracker *ConfigHashTracker
+
+	// ReadinessGate, when set, holds configuration pushes back (failing them with UpdateSkippedDueToReadinessGateError)
+	// until it opens, e.g. to avoid pushing a partial configuration before caches are warm on the controller's startup.
+	// When nil, configuration is pushed right away.
+	ReadinessGate ReadinessGate
+
+	// SensitiveFields are names of fields (e.g. secrets of custom plugins) whose values are redacted from
+	// configuration snippets included in errors returned (and logged) when Kong rejects the configuration,
+	// on top of the well-known sensitive fields (e.g. credentials' secrets) that are always redacted.
+	SensitiveFields []string
+
+	// GeneratedConfigLogLevel, when positive, makes every configuration push log the whole generated configuration
+	// (with values of SensitiveFields and the well-known sensitive fields redacted) at this verbosity, for debugging
+	// configuration generation. Verbosities lower than trace level are raised to it, as the configuration may be
+	// large and sensitive.
+	GeneratedConfigLogLevel int
+
+	// PushDurationAverage, when set, is updated with durations of successful configuration pushes, so a recent
+	// average push latency of every data-plane is available programmatically.
+	PushDurationAverage *PushDurationAverage
+
+	// SyncScopeTags, when set, scopes the configuration push to entities tagged with all of them (e.g. a single
+	// namespace's entities). Both the target configuration and the current state dump are limited to such entities,
+	// so entities out of the scope are left untouched instead of being deleted. It's supported only in DB mode.
+	// Scoped pushes do not update the last pushed configuration SHA as they do not sync the whole configuration.
+	SyncScopeTags []string
+
+	// ContentTransformers are run (in order) on the generated configuration before it's pushed, both in DB-less
+	// and DB mode, allowing its custom post-processing (e.g. injecting global plugins or rewriting tags).
+	// Configuration SHA is calculated after they're run. An error returned from any of them aborts the push.
+	ContentTransformers []ContentTransformFunc
+
+	// OnApplied, when set, is called after every successful configuration push (e.g. to trigger downstream actions
+	// like cache warming). It's not called when the push is skipped because the configuration hasn't changed.
+	// For pushes scoped by tags, changed is based on the applied diff as their SHA is not tracked.
+	OnApplied OnAppliedFunc
+
+	// ForcedResync, when set, periodically forces a sync of the whole configuration to every data-plane, even if
+	// the configuration's SHA hasn't changed (equivalent to a one-shot EnableReverseSync), to self-heal a silent drift.
+	ForcedResync *ForcedResync
+
+	// InitialHashGracePeriod, when set, defers re-syncs of data-planes reporting the initial configuration hash
+	// (e.g. after a restart) by a random grace period, so that controller replicas don't all push to them at once.
+	InitialHashGracePeriod *InitialHashGracePeriod
+
+	// EmptyConfigGuard, when set, makes PerformUpdate refuse (with EmptyConfigGuardError) pushing configurations
+	// that are empty or much smaller than the one last applied, so that Kong isn't wiped out by accident.
+	EmptyConfigGuard *EmptyConfigGuard
+
+	// SyncPause, when set, lets configuration syncing be paused at runtime. See SyncPause for details.
+	SyncPause *SyncPause
+
+	// ConflictCircuitBreaker, when set, makes PerformUpdate fail fast with UpdateSkippedDueToOpenCircuitError
+	// instead of pushing configuration to a data-plane that has repeatedly rejected it due to conflicts.
+	ConflictCircuitBreaker *ConflictCircuitBreaker
+
+	// ValidatePluginSchemas enables validating plugins' configs against their schemas fetched from Kong (and cached
+	// per data-plane) before pushing configuration, so that schema violations fail fast with precise field errors
+	// (deckerrors.SchemaValidationError) instead of failing the whole push.
+	ValidatePluginSchemas bool
+
+	// ValidateCertificates enables validating certificates in the configuration before pushing it, so that
+	// certificates that can't be parsed or don't match their private keys fail fast with an error naming the Secret
+	// they were generated from (deckerrors.CertificateValidationError). Certificates expiring soon are warned about.
+	ValidateCertificates bool
+
+	// CheckPluginOrdering enables checking dynamic ordering of plugins (the `ordering` field) in the configuration
+	// before pushing it, logging a warning when their constraints conflict (e.g. form a cycle), which would make
+	// the plugins' execution order differ from the expected one. The configuration is pushed regardless.
+	CheckPluginOrdering bool
+
+	// PushCoalescer, when set, makes PerformUpdate drop pushes superseded by newer ones while waiting for an in-flight
+	// push to the same data-plane to complete, failing them with UpdateSkippedDueToCoalescingError.
+	PushCoalescer *PushCoalescer
+
+	// PushRateLimiter, when set, limits the rate of configuration pushes to every data-plane, making PerformUpdate
+	// wait for its turn or fail with UpdateSkippedDueToRateLimitError. Syncs skipped due to no configuration change
+	// don't count towards the limit.
+	PushRateLimiter *PushRateLimiter
+
+	// PushRetryPolicy configures retries of configuration pushes that failed due to transient
+	// (network or Admin API server-side) errors, including Kong being unavailable while restarting.
+	// Retries are disabled by default.
+	PushRetryPolicy RetryPolicy
 }
 
 // Init sets up variables that need external calls.
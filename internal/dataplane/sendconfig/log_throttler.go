@@ -0,0 +1,113 @@
+package sendconfig
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// SHALogThrottler limits how often a log line about a configuration with the same SHA is emitted for a data-plane.
+// A log line is allowed at most once per interval for the same data-plane and SHA, and immediately when the SHA changes.
+// A nil SHALogThrottler allows all log lines.
+type SHALogThrottler struct {
+	interval time.Duration
+
+	lock    sync.Mutex
+	entries map[string]shaLogEntry
+}
+
+type shaLogEntry struct {
+	sha      []byte
+	loggedAt time.Time
+}
+
+func NewSHALogThrottler(interval time.Duration) *SHALogThrottler {
+	return &SHALogThrottler{
+		interval: interval,
+		entries:  map[string]shaLogEntry{},
+	}
+}
+
+// ShouldLog tells whether a log line about the configuration SHA should be emitted for the data-plane.
+// If so, it's recorded as emitted.
+func (t *SHALogThrottler) ShouldLog(dataplane string, sha []byte) bool {
+	if t == nil {
+		return true
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	if entry, ok := t.entries[dataplane]; ok && bytes.Equal(entry.sha, sha) && now.Sub(entry.loggedAt) < t.interval {
+		return false
+	}
+	t.entries[dataplane] = shaLogEntry{sha: sha, loggedAt: now}
+	return true
+}
+
+// DecayingLogThrottler limits how often a log line about a condition persisting for a data-plane (e.g. slow pushes)
+// is emitted. The first line is emitted right away, then the interval between lines doubles with every emitted line
+// (starting at the initial interval, up to the max one) while the condition persists. Once the condition is resolved
+// (see Reset), the next line is emitted right away again.
+// A nil DecayingLogThrottler allows all log lines.
+type DecayingLogThrottler struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	now             func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]decayingLogEntry
+}
+
+type decayingLogEntry struct {
+	nextAt     time.Time
+	interval   time.Duration
+	suppressed int
+}
+
+func NewDecayingLogThrottler(initialInterval, maxInterval time.Duration) *DecayingLogThrottler {
+	return &DecayingLogThrottler{
+		initialInterval: initialInterval,
+		maxInterval:     maxInterval,
+		now:             time.Now,
+		entries:         map[string]decayingLogEntry{},
+	}
+}
+
+// ShouldLog tells whether a log line about the condition should be emitted for the data-plane, along with the number
+// of lines suppressed since the last emitted one. If so, it's recorded as emitted.
+func (t *DecayingLogThrottler) ShouldLog(dataplane string) (bool, int) {
+	if t == nil {
+		return true, 0
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	entry, ok := t.entries[dataplane]
+	if ok && now.Before(entry.nextAt) {
+		entry.suppressed++
+		t.entries[dataplane] = entry
+		return false, 0
+	}
+
+	interval := t.initialInterval
+	if ok {
+		interval = min(entry.interval*2, t.maxInterval)
+	}
+	t.entries[dataplane] = decayingLogEntry{nextAt: now.Add(interval), interval: interval}
+	return true, entry.suppressed
+}
+
+// Reset records the condition has been resolved for the data-plane.
+func (t *DecayingLogThrottler) Reset(dataplane string) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.entries, dataplane)
+}
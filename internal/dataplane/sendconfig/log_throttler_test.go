@@ -0,0 +1,55 @@
+package sendconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecayingLogThrottler(t *testing.T) {
+	const dataplane = "https://kong:8444"
+
+	t.Run("nil allows all log lines", func(t *testing.T) {
+		var th *DecayingLogThrottler
+		shouldLog, _ := th.ShouldLog(dataplane)
+		require.True(t, shouldLog)
+		th.Reset(dataplane)
+	})
+
+	t.Run("interval between log lines doubles while the condition persists", func(t *testing.T) {
+		now := time.Now()
+		th := NewDecayingLogThrottler(time.Minute, 3*time.Minute)
+		th.now = func() time.Time { return now }
+
+		// Checks whether a line is emitted at every minute, returning the numbers of suppressed lines reported.
+		shouldLogEveryMinute := func(minutes int) []int {
+			var reported []int
+			for i := 0; i < minutes; i++ {
+				if shouldLog, suppressed := th.ShouldLog(dataplane); shouldLog {
+					reported = append(reported, suppressed)
+				}
+				now = now.Add(time.Minute)
+			}
+			return reported
+		}
+
+		// Lines are emitted at minutes 0, 1, 3, 6 (the interval is capped at 3 minutes) and 9.
+		require.Equal(t, []int{0, 0, 1, 2, 2}, shouldLogEveryMinute(10))
+
+		t.Run("log line is emitted right away once the condition has been resolved", func(t *testing.T) {
+			th.Reset(dataplane)
+			require.Equal(t, []int{0, 0, 1}, shouldLogEveryMinute(4))
+		})
+	})
+
+	t.Run("data-planes are throttled independently", func(t *testing.T) {
+		th := NewDecayingLogThrottler(time.Hour, time.Hour)
+		shouldLog, _ := th.ShouldLog(dataplane)
+		require.True(t, shouldLog)
+		shouldLog, _ = th.ShouldLog(dataplane)
+		require.False(t, shouldLog)
+		shouldLog, _ = th.ShouldLog("https://other-kong:8444")
+		require.True(t, shouldLog)
+	})
+}
@@ -0,0 +1,119 @@
+package sendconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// checkPluginOrdering logs a warning for every problem with the dynamic ordering (the `ordering` field, see
+// kong.PluginOrdering) of the content's plugins (see pluginOrderingProblems). The configuration is pushed regardless,
+// as Kong doesn't reject it, but the plugins' execution order may not be the expected one.
+func checkPluginOrdering(logger logr.Logger, content *file.Content) {
+	for _, problem := range pluginOrderingProblems(content) {
+		logger.Error(nil, "Plugin ordering is conflicting, plugins may be executed in an unexpected order", "problem", problem)
+	}
+}
+
+// pluginOrderingProblems returns problems with the dynamic ordering of the content's plugins making their execution
+// order within a phase conflicting or ambiguous:
+//   - a plugin ordered relative to itself,
+//   - ordering constraints of plugins forming a cycle (e.g. a plugin configured to run before another one, which is
+//     configured to run before the first one), in which case they can't all be satisfied.
+//
+// Constraints of all the plugin instances are taken into account, as Kong orders plugins by their names.
+func pluginOrderingProblems(content *file.Content) []string {
+	// runsBefore holds edges of the ordering graph of every phase: runsBefore[phase][a] contains b when a plugin
+	// named a has to run before a plugin named b.
+	runsBefore := map[string]map[string]sets.Set[string]{}
+	addEdge := func(phase, before, after string) {
+		if _, ok := runsBefore[phase]; !ok {
+			runsBefore[phase] = map[string]sets.Set[string]{}
+		}
+		if _, ok := runsBefore[phase][before]; !ok {
+			runsBefore[phase][before] = sets.New[string]()
+		}
+		runsBefore[phase][before].Insert(after)
+	}
+
+	selfReferences := sets.New[string]()
+	for _, plugin := range contentPlugins(content) {
+		if plugin.Name == nil || plugin.Ordering == nil {
+			continue
+		}
+		name := *plugin.Name
+		for phase, others := range plugin.Ordering.Before {
+			for _, other := range others {
+				if other == name {
+					selfReferences.Insert(fmt.Sprintf("plugin %s is ordered relative to itself in phase %s", name, phase))
+					continue
+				}
+				addEdge(phase, name, other)
+			}
+		}
+		for phase, others := range plugin.Ordering.After {
+			for _, other := range others {
+				if other == name {
+					selfReferences.Insert(fmt.Sprintf("plugin %s is ordered relative to itself in phase %s", name, phase))
+					continue
+				}
+				addEdge(phase, other, name)
+			}
+		}
+	}
+
+	problems := sets.List(selfReferences)
+	phases := lo.Keys(runsBefore)
+	sort.Strings(phases)
+	for _, phase := range phases {
+		for _, cycle := range orderingCycles(runsBefore[phase]) {
+			problems = append(problems, fmt.Sprintf("plugins %s have cyclic ordering in phase %s", strings.Join(cycle, " -> "), phase))
+		}
+	}
+	return problems
+}
+
+// orderingCycles returns cycles found in the ordering graph (each of them starting and ending with the same plugin).
+// Every plugin is reported in at most one cycle.
+func orderingCycles(runsBefore map[string]sets.Set[string]) [][]string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	var (
+		cycles [][]string
+		state  = map[string]int{}
+		path   []string
+		visit  func(name string)
+	)
+	visit = func(name string) {
+		state[name] = inProgress
+		path = append(path, name)
+		for _, next := range sets.List(runsBefore[name]) {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case inProgress:
+				start := lo.IndexOf(path, next)
+				cycles = append(cycles, append(append([]string{}, path[start:]...), next))
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+	}
+
+	names := lo.Keys(runsBefore)
+	sort.Strings(names)
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,95 @@
+package sendconfig
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginOrderingProblems(t *testing.T) {
+	plugin := func(name string, ordering *kong.PluginOrdering) file.FPlugin {
+		return file.FPlugin{Plugin: kong.Plugin{Name: kong.String(name), Ordering: ordering}}
+	}
+	runsBefore := func(others ...string) *kong.PluginOrdering {
+		return &kong.PluginOrdering{Before: kong.PluginOrderingPhase{"access": others}}
+	}
+	runsAfter := func(others ...string) *kong.PluginOrdering {
+		return &kong.PluginOrdering{After: kong.PluginOrderingPhase{"access": others}}
+	}
+
+	testCases := []struct {
+		name             string
+		content          *file.Content
+		expectedProblems []string
+	}{
+		{
+			name: "no ordering",
+			content: &file.Content{
+				Plugins: []file.FPlugin{plugin("key-auth", nil), plugin("rate-limiting", nil)},
+			},
+		},
+		{
+			name: "consistent ordering",
+			content: &file.Content{
+				Plugins: []file.FPlugin{
+					plugin("rate-limiting", runsBefore("key-auth")),
+					plugin("key-auth", runsBefore("acl")),
+					plugin("acl", runsAfter("rate-limiting")),
+				},
+			},
+		},
+		{
+			name: "plugin ordered relative to itself",
+			content: &file.Content{
+				Plugins: []file.FPlugin{plugin("key-auth", runsBefore("key-auth"))},
+			},
+			expectedProblems: []string{"plugin key-auth is ordered relative to itself in phase access"},
+		},
+		{
+			name: "plugin ordered both before and after another one",
+			content: &file.Content{
+				Plugins: []file.FPlugin{plugin("rate-limiting", &kong.PluginOrdering{
+					Before: kong.PluginOrderingPhase{"access": []string{"key-auth"}},
+					After:  kong.PluginOrderingPhase{"access": []string{"key-auth"}},
+				})},
+			},
+			expectedProblems: []string{"plugins key-auth -> rate-limiting -> key-auth have cyclic ordering in phase access"},
+		},
+		{
+			name: "cycle across plugins attached to different entities",
+			content: &file.Content{
+				Services: []file.FService{{
+					Plugins: []*file.FPlugin{lo.ToPtr(plugin("acl", runsBefore("rate-limiting")))},
+					Routes: []*file.FRoute{{
+						Plugins: []*file.FPlugin{lo.ToPtr(plugin("key-auth", runsBefore("acl")))},
+					}},
+				}},
+				Plugins: []file.FPlugin{plugin("rate-limiting", runsBefore("key-auth"))},
+			},
+			expectedProblems: []string{"plugins acl -> rate-limiting -> key-auth -> acl have cyclic ordering in phase access"},
+		},
+		{
+			name: "constraints in different phases don't conflict",
+			content: &file.Content{
+				Plugins: []file.FPlugin{
+					plugin("rate-limiting", runsBefore("key-auth")),
+					plugin("key-auth", &kong.PluginOrdering{Before: kong.PluginOrderingPhase{"log": []string{"rate-limiting"}}}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := pluginOrderingProblems(tc.content)
+			if len(tc.expectedProblems) == 0 {
+				require.Empty(t, problems)
+				return
+			}
+			require.Equal(t, tc.expectedProblems, problems)
+		})
+	}
+}
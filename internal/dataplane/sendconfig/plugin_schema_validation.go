@@ -0,0 +1,263 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+)
+
+// pluginSchemaGetter returns Kong's full schema of a plugin. It's implemented by util.PluginSchemaStore that caches
+// the schemas, so that they're fetched from Kong's `/schemas/plugins/:name` endpoint only once.
+type pluginSchemaGetter interface {
+	Schema(ctx context.Context, pluginName string) (map[string]interface{}, error)
+}
+
+// validatePluginSchemas validates configs of the content's plugins against their schemas, returning errors
+// associated with Kubernetes resources the invalid plugins were generated from along with
+// deckerrors.SchemaValidationError describing all the violations. Only violations that Kong is certain to reject the
+// configuration for are reported: unknown fields, missing required fields with no default, values of a wrong type
+// and values out of the allowed set. Plugins whose schemas couldn't be fetched (for reasons other than the plugin not
+// existing) are not validated.
+func validatePluginSchemas(
+	ctx context.Context, logger logr.Logger, schemas pluginSchemaGetter, content *file.Content,
+) ([]ResourceError, error) {
+	var (
+		problems       []string
+		resourceErrors []ResourceError
+	)
+	for _, plugin := range contentPlugins(content) {
+		if plugin.Name == nil {
+			continue
+		}
+		name := *plugin.Name
+
+		var pluginProblems map[string]string
+		schema, err := schemas.Schema(ctx, name)
+		switch {
+		case kong.IsNotFoundErr(err):
+			pluginProblems = map[string]string{"name": fmt.Sprintf("plugin '%s' not enabled; add it to 'plugins' configuration property", name)}
+		case err != nil:
+			logger.Error(err, "Failed to fetch plugin schema, skipping its validation", "plugin", name)
+			continue
+		default:
+			pluginProblems = validatePluginConfig(schema, plugin.Config)
+		}
+		if len(pluginProblems) == 0 {
+			continue
+		}
+
+		fields := lo.Keys(pluginProblems)
+		sort.Strings(fields)
+		for _, field := range fields {
+			problems = append(problems, fmt.Sprintf("plugin %s: %s: %s", name, field, pluginProblems[field]))
+		}
+		resourceError, err := parseRawResourceError(rawResourceError{
+			Name:     name,
+			ID:       lo.FromPtr(plugin.ID),
+			Tags:     lo.Map(plugin.Tags, func(t *string, _ int) string { return lo.FromPtr(t) }),
+			Problems: pluginProblems,
+		})
+		if err != nil {
+			logger.Error(err, "Entity tags missing fields", "name", name)
+			continue
+		}
+		resourceErrors = append(resourceErrors, resourceError)
+	}
+
+	if len(problems) > 0 {
+		return resourceErrors, deckerrors.SchemaValidationError{Problems: problems}
+	}
+	return nil, nil
+}
+
+// validatePluginConfig validates a plugin's config against its full schema, returning problems keyed by the paths
+// of invalid fields (e.g. "config.policy").
+func validatePluginConfig(schema map[string]interface{}, config kong.Configuration) map[string]string {
+	configSchema, ok := schemaField(schema["fields"], "config")
+	if !ok {
+		return nil
+	}
+	problems := map[string]string{}
+	validateRecord(problems, "config", map[string]interface{}(config), configSchema)
+	return problems
+}
+
+// validateRecord validates a record (an object with fields known upfront) against its schema.
+func validateRecord(problems map[string]string, path string, record map[string]interface{}, schema map[string]interface{}) {
+	fields, _ := schema["fields"].([]interface{})
+	shorthandFields, _ := schema["shorthand_fields"].([]interface{})
+
+	known := map[string]struct{}{}
+	for _, list := range [][]interface{}{fields, shorthandFields} {
+		for _, f := range list {
+			for name := range asMap(f) {
+				known[name] = struct{}{}
+			}
+		}
+	}
+	for name := range record {
+		if _, ok := known[name]; !ok {
+			problems[path+"."+name] = "unknown field"
+		}
+	}
+
+	for _, f := range fields {
+		for name, fieldSchema := range asMap(f) {
+			fieldSchema := asMap(fieldSchema)
+			value, ok := record[name]
+			if !ok || value == nil {
+				// Kong fills in the defaults of missing fields (for records, from the defaults of their fields).
+				required, _ := fieldSchema["required"].(bool)
+				if required && fieldSchema["default"] == nil && fieldSchema["type"] != "record" {
+					problems[path+"."+name] = "required field missing"
+				}
+				continue
+			}
+			validateValue(problems, path+"."+name, value, fieldSchema)
+		}
+	}
+}
+
+// validateValue validates a value against its field schema.
+func validateValue(problems map[string]string, path string, value interface{}, schema map[string]interface{}) {
+	v := reflect.ValueOf(value)
+	switch schema["type"] {
+	case "string":
+		if v.Kind() != reflect.String {
+			problems[path] = "expected a string"
+			return
+		}
+	case "integer":
+		if !isInteger(v) {
+			problems[path] = "expected an integer"
+			return
+		}
+	case "number":
+		if !isNumber(v) {
+			problems[path] = "expected a number"
+			return
+		}
+	case "boolean":
+		if v.Kind() != reflect.Bool {
+			problems[path] = "expected a boolean"
+			return
+		}
+	case "array", "set":
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			problems[path] = fmt.Sprintf("expected %s", lo.Ternary(schema["type"] == "array", "an array", "a set"))
+			return
+		}
+		elements := asMap(schema["elements"])
+		for i := 0; i < v.Len(); i++ {
+			validateValue(problems, fmt.Sprintf("%s[%d]", path, i), v.Index(i).Interface(), elements)
+		}
+		return
+	case "map":
+		if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+			problems[path] = "expected a map"
+			return
+		}
+		values := asMap(schema["values"])
+		for _, key := range v.MapKeys() {
+			validateValue(problems, path+"."+key.String(), v.MapIndex(key).Interface(), values)
+		}
+		return
+	case "record":
+		record, ok := toStringKeyedMap(v)
+		if !ok {
+			problems[path] = "expected a record"
+			return
+		}
+		validateRecord(problems, path, record, schema)
+		return
+	default:
+		// Other types (e.g. "foreign") aren't validated.
+		return
+	}
+
+	if oneOf, ok := schema["one_of"].([]interface{}); ok && len(oneOf) > 0 {
+		if !lo.ContainsBy(oneOf, func(allowed interface{}) bool { return fmt.Sprint(allowed) == fmt.Sprint(value) }) {
+			problems[path] = fmt.Sprintf("expected one of: %s", strings.Join(lo.Map(oneOf, func(allowed interface{}, _ int) string {
+				return fmt.Sprint(allowed)
+			}), ", "))
+		}
+	}
+}
+
+// schemaField returns the schema of the named field from a list of fields (single-key objects as returned by Kong).
+func schemaField(fields interface{}, name string) (map[string]interface{}, bool) {
+	list, _ := fields.([]interface{})
+	for _, f := range list {
+		if fieldSchema, ok := asMap(f)[name]; ok {
+			return asMap(fieldSchema), true
+		}
+	}
+	return nil, false
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func toStringKeyedMap(v reflect.Value) (map[string]interface{}, bool) {
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, v.Len())
+	for _, key := range v.MapKeys() {
+		m[key.String()] = v.MapIndex(key).Interface()
+	}
+	return m, true
+}
+
+// isInteger tells whether the value is an integer. JSON numbers are decoded as floats, hence whole floats
+// are accepted as well.
+func isInteger(v reflect.Value) bool {
+	if !isNumber(v) {
+		return false
+	}
+	f := v.Convert(reflect.TypeOf(float64(0))).Float()
+	return f == math.Trunc(f)
+}
+
+// contentPlugins returns all the content's plugins, including the ones nested in other entities.
+func contentPlugins(content *file.Content) []*file.FPlugin {
+	var plugins []*file.FPlugin
+	addRoutes := func(routes []*file.FRoute) {
+		for _, r := range routes {
+			plugins = append(plugins, r.Plugins...)
+		}
+	}
+	for _, s := range content.Services {
+		plugins = append(plugins, s.Plugins...)
+		addRoutes(s.Routes)
+	}
+	addRoutes(lo.ToSlicePtr(content.Routes))
+	for _, c := range content.Consumers {
+		plugins = append(plugins, c.Plugins...)
+	}
+	plugins = append(plugins, lo.ToSlicePtr(content.Plugins)...)
+	return plugins
+}
@@ -0,0 +1,197 @@
+package sendconfig_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+// rateLimitingSchema is a trimmed down full schema of the rate-limiting plugin as returned by Kong.
+const rateLimitingSchema = `{
+  "fields": [
+    {"name": {"type": "string", "required": true}},
+    {"config": {
+      "type": "record",
+      "required": true,
+      "fields": [
+        {"minute": {"type": "number"}},
+        {"limit_by": {"type": "string", "default": "consumer", "one_of": ["consumer", "credential", "ip", "header"]}},
+        {"fault_tolerant": {"type": "boolean", "default": true}},
+        {"header_name": {"type": "string", "required": true}},
+        {"error_code": {"type": "integer", "default": 429}},
+        {"path_prefixes": {"type": "array", "elements": {"type": "string"}}},
+        {"redis": {"type": "record", "required": true, "fields": [
+          {"port": {"type": "integer", "default": 6379}}
+        ]}}
+      ],
+      "shorthand_fields": [
+        {"redis_port": {"type": "integer"}}
+      ]
+    }}
+  ]
+}`
+
+func newPluginSchemasAdminAPIServer(t *testing.T, schemaRequests *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/plugins/rate-limiting":
+			schemaRequests.Add(1)
+			_, _ = w.Write([]byte(rateLimitingSchema))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func pluginContent(name string, config kong.Configuration) *file.Content {
+	return &file.Content{
+		Plugins: []file.FPlugin{
+			{
+				Plugin: kong.Plugin{
+					Name:   kong.String(name),
+					Config: config,
+					Tags: kong.StringSlice(
+						"k8s-name:rate-limit",
+						"k8s-namespace:default",
+						"k8s-kind:KongPlugin",
+						"k8s-group:configuration.konghq.com",
+						"k8s-version:v1",
+						"k8s-uid:a3b8afcc-9f19-42e4-aa8f-5866168c2ad3",
+					),
+				},
+			},
+		},
+	}
+}
+
+func TestPerformUpdate_ValidatePluginSchemas(t *testing.T) {
+	testCases := []struct {
+		name             string
+		plugin           string
+		config           kong.Configuration
+		expectedProblems []string
+	}{
+		{
+			name:   "valid config",
+			plugin: "rate-limiting",
+			config: kong.Configuration{
+				"minute":        float64(5),
+				"limit_by":      "header",
+				"header_name":   "x-user",
+				"error_code":    float64(503),
+				"path_prefixes": []interface{}{"/api"},
+				"redis_port":    float64(6380),
+				"redis":         map[string]interface{}{"port": 6380},
+			},
+		},
+		{
+			name:   "invalid config",
+			plugin: "rate-limiting",
+			config: kong.Configuration{
+				"minute":         "5",
+				"limit_by":       "service",
+				"fault_tolerant": "yes",
+				"error_code":     float64(4.5),
+				"path_prefixes":  []interface{}{"/api", 1},
+				"redis":          map[string]interface{}{"host": "redis"},
+				"unknown":        true,
+			},
+			expectedProblems: []string{
+				"plugin rate-limiting: config.error_code: expected an integer",
+				"plugin rate-limiting: config.fault_tolerant: expected a boolean",
+				"plugin rate-limiting: config.header_name: required field missing",
+				"plugin rate-limiting: config.limit_by: expected one of: consumer, credential, ip, header",
+				"plugin rate-limiting: config.minute: expected a number",
+				"plugin rate-limiting: config.path_prefixes[1]: expected a string",
+				"plugin rate-limiting: config.redis.host: unknown field",
+				"plugin rate-limiting: config.unknown: unknown field",
+			},
+		},
+		{
+			name:   "unknown plugin",
+			plugin: "unknown",
+			expectedProblems: []string{
+				"plugin unknown: name: plugin 'unknown' not enabled; add it to 'plugins' configuration property",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var schemaRequests atomic.Int32
+			server := newPluginSchemasAdminAPIServer(t, &schemaRequests)
+			client, err := adminapi.NewTestClient(server.URL)
+			require.NoError(t, err)
+			strategy := &diffReportingUpdateStrategy{}
+
+			_, resourceFailures, err := sendconfig.PerformUpdate(
+				context.Background(), logr.Discard(), client, sendconfig.Config{ValidatePluginSchemas: true},
+				pluginContent(tc.plugin, tc.config), metrics.NewCtrlFuncMetrics(),
+				staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+			)
+			if len(tc.expectedProblems) == 0 {
+				require.NoError(t, err)
+				require.True(t, strategy.wasCalled)
+				return
+			}
+
+			var validationErr deckerrors.SchemaValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tc.expectedProblems, validationErr.Problems)
+			require.False(t, strategy.wasCalled, "configuration failing validation shouldn't be pushed")
+			require.Len(t, resourceFailures, len(tc.expectedProblems), "every problem should be reported as a failure")
+			require.Equal(t, "rate-limit", resourceFailures[0].CausingObjects()[0].GetName())
+			pushErr := sendconfig.PushError{}
+			require.ErrorAs(t, err, &pushErr)
+			require.Equal(t, metrics.FailureReasonValidation, pushErr.FailureReason)
+		})
+	}
+
+	t.Run("schemas are cached", func(t *testing.T) {
+		var schemaRequests atomic.Int32
+		server := newPluginSchemasAdminAPIServer(t, &schemaRequests)
+		client, err := adminapi.NewTestClient(server.URL)
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, _, err := sendconfig.PerformUpdate(
+				context.Background(), logr.Discard(), client, sendconfig.Config{ValidatePluginSchemas: true},
+				pluginContent("rate-limiting", kong.Configuration{"header_name": "x-user"}), nil,
+				staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+			)
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(1), schemaRequests.Load())
+	})
+
+	t.Run("validation is disabled by default", func(t *testing.T) {
+		var schemaRequests atomic.Int32
+		server := newPluginSchemasAdminAPIServer(t, &schemaRequests)
+		client, err := adminapi.NewTestClient(server.URL)
+		require.NoError(t, err)
+
+		_, _, err = sendconfig.PerformUpdate(
+			context.Background(), logr.Discard(), client, sendconfig.Config{},
+			pluginContent("rate-limiting", kong.Configuration{"unknown": true}), nil,
+			staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+		require.Zero(t, schemaRequests.Load())
+	})
+}
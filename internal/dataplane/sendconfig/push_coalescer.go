@@ -0,0 +1,60 @@
+package sendconfig
+
+import (
+	"sync"
+)
+
+// UpdateSkippedDueToCoalescingError is returned from PerformUpdate when the configuration push was dropped due to
+// Config.PushCoalescer as a newer configuration for the same data-plane had been requested while it was waiting for
+// an in-flight push to complete.
+type UpdateSkippedDueToCoalescingError struct{}
+
+func (e UpdateSkippedDueToCoalescingError) Error() string {
+	return "update skipped due to being superseded by a newer configuration"
+}
+
+// PushCoalescer coalesces configuration pushes to a data-plane requested while another push to it is in flight.
+// Pushes to a data-plane are serialized, so without it every push waiting for the in-flight one would be performed
+// in turn, even though all of them but the latest are redundant. With it, the waiting pushes effectively share
+// a single pending slot: when the in-flight push completes, only the latest requested push is performed, while
+// the others fail fast with UpdateSkippedDueToCoalescingError.
+// A nil PushCoalescer never drops pushes.
+type PushCoalescer struct {
+	lock   sync.Mutex
+	latest map[string]uint64 // Tickets of the latest requested pushes keyed by data-plane URL.
+}
+
+// NewPushCoalescer returns a PushCoalescer.
+func NewPushCoalescer() *PushCoalescer {
+	return &PushCoalescer{
+		latest: map[string]uint64{},
+	}
+}
+
+// request registers a push to the data-plane, returning its ticket. It's meant to be called before waiting for
+// an in-flight push to complete.
+func (c *PushCoalescer) request(dataplane string) uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.latest[dataplane]++
+	return c.latest[dataplane]
+}
+
+// superseded returns UpdateSkippedDueToCoalescingError when a push to the data-plane has been requested after
+// the one with the ticket. It's meant to be called once the in-flight push has completed.
+func (c *PushCoalescer) superseded(dataplane string, ticket uint64) error {
+	if c == nil {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.latest[dataplane] != ticket {
+		return UpdateSkippedDueToCoalescingError{}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package sendconfig
+
+import (
+	"sync"
+	"time"
+)
+
+// PushDurationAverage maintains an exponentially weighted moving average (EWMA) of successful configuration push
+// durations per data-plane. It's meant to be consulted programmatically, e.g. for tuning backoff, concurrency, or
+// timeouts based on the recent Admin API latency. A nil PushDurationAverage records nothing.
+type PushDurationAverage struct {
+	alpha float64
+
+	lock     sync.RWMutex
+	averages map[string]time.Duration // Keyed by data-plane URL.
+}
+
+// NewPushDurationAverage returns a PushDurationAverage weighting every new observation by alpha, which has to be
+// in the (0, 1] range. The higher alpha is, the faster older observations are discounted.
+func NewPushDurationAverage(alpha float64) *PushDurationAverage {
+	return &PushDurationAverage{
+		alpha:    alpha,
+		averages: map[string]time.Duration{},
+	}
+}
+
+// Observe records a duration of a successful configuration push to the data-plane. The first observation
+// for a data-plane initializes its average.
+func (a *PushDurationAverage) Observe(dataplane string, d time.Duration) {
+	if a == nil {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	average, ok := a.averages[dataplane]
+	if !ok {
+		a.averages[dataplane] = d
+		return
+	}
+	a.averages[dataplane] = time.Duration(a.alpha*float64(d) + (1-a.alpha)*float64(average))
+}
+
+// Average returns the current average push duration for the data-plane. False is returned when no push
+// to the data-plane has been observed yet.
+func (a *PushDurationAverage) Average(dataplane string) (time.Duration, bool) {
+	if a == nil {
+		return 0, false
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	average, ok := a.averages[dataplane]
+	return average, ok
+}
@@ -0,0 +1,80 @@
+package sendconfig_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+func TestPushDurationAverage(t *testing.T) {
+	const dataplane = "https://kong:8444"
+
+	t.Run("no observations", func(t *testing.T) {
+		a := sendconfig.NewPushDurationAverage(0.5)
+		_, ok := a.Average(dataplane)
+		require.False(t, ok)
+	})
+
+	t.Run("first observation initializes average", func(t *testing.T) {
+		a := sendconfig.NewPushDurationAverage(0.5)
+		a.Observe(dataplane, time.Second)
+		average, ok := a.Average(dataplane)
+		require.True(t, ok)
+		require.Equal(t, time.Second, average)
+	})
+
+	t.Run("observations are weighted by alpha", func(t *testing.T) {
+		a := sendconfig.NewPushDurationAverage(0.25)
+		a.Observe(dataplane, time.Second)
+		a.Observe(dataplane, 5*time.Second)
+		average, _ := a.Average(dataplane)
+		require.Equal(t, 2*time.Second, average)
+
+		_, ok := a.Average("https://other-kong:8444")
+		require.False(t, ok, "averages should be tracked per data-plane")
+	})
+
+	t.Run("nil average is a no-op", func(t *testing.T) {
+		var a *sendconfig.PushDurationAverage
+		a.Observe(dataplane, time.Second)
+		_, ok := a.Average(dataplane)
+		require.False(t, ok)
+	})
+
+	t.Run("concurrent observations", func(t *testing.T) {
+		a := sendconfig.NewPushDurationAverage(0.5)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				a.Observe(dataplane, time.Second)
+				_, _ = a.Average(dataplane)
+			}()
+		}
+		wg.Wait()
+		average, _ := a.Average(dataplane)
+		require.Equal(t, time.Second, average)
+	})
+}
+
+func TestPerformUpdate_PushDurationAverage(t *testing.T) {
+	client := mustTestClient(t)
+	average := sendconfig.NewPushDurationAverage(0.5)
+
+	_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client,
+		sendconfig.Config{PushDurationAverage: average}, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}},
+		staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	_, ok := average.Average(client.BaseRootURL())
+	require.True(t, ok)
+}
@@ -0,0 +1,47 @@
+package sendconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+// PushError is returned from PerformUpdate when pushing the configuration failed. It carries details of the failure
+// letting callers decide how to handle it (e.g. whether to requeue) without inspecting the wrapped error.
+type PushError struct {
+	Err error
+
+	// StatusCode is the HTTP status code of the Admin API response the push failed with. It's 0 when the push
+	// failed without a response (e.g. due to a network error).
+	StatusCode int
+
+	// FailureReason is one of metrics.FailureReason* constants, as reported in the push metrics.
+	FailureReason string
+
+	// Retriable tells whether the failure is transient and the same push may succeed when retried.
+	Retriable bool
+}
+
+func newPushError(err error) PushError {
+	pushErr := PushError{
+		Err:           err,
+		FailureReason: metrics.PushFailureReason(err),
+		// Pushes canceled due to the controller shutting down are not worth retrying.
+		Retriable: isRetriableUpdateError(err) && !errors.Is(err, context.Canceled),
+	}
+	if codes := deckerrors.StatusCodes(err); len(codes) > 0 {
+		pushErr.StatusCode = codes[0]
+	}
+	return pushErr
+}
+
+func (e PushError) Error() string {
+	return fmt.Sprintf("configuration push failed (reason: %s): %v", e.FailureReason, e.Err)
+}
+
+func (e PushError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,98 @@
+package sendconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	deckutils "github.com/kong/deck/utils"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+func TestNewPushError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		expected      PushError
+		expectedIsErr error
+	}{
+		{
+			name: "server error",
+			err:  kong.NewAPIError(http.StatusInternalServerError, "internal error"),
+			expected: PushError{
+				StatusCode:    http.StatusInternalServerError,
+				FailureReason: metrics.FailureReasonOther,
+				Retriable:     true,
+			},
+		},
+		{
+			name: "validation error",
+			err:  kong.NewAPIError(http.StatusBadRequest, "invalid config"),
+			expected: PushError{
+				StatusCode:    http.StatusBadRequest,
+				FailureReason: metrics.FailureReasonValidation,
+			},
+		},
+		{
+			name: "conflict in deck errors",
+			err:  deckutils.ErrArray{Errors: []error{kong.NewAPIError(http.StatusConflict, "conflict")}},
+			expected: PushError{
+				StatusCode:    http.StatusConflict,
+				FailureReason: metrics.FailureReasonConflict,
+			},
+		},
+		{
+			name: "DB-less configuration push error response",
+			err:  deckerrors.ConfigStatusError{StatusCode: http.StatusBadGateway, Err: errors.New("got status code 502")},
+			expected: PushError{
+				StatusCode:    http.StatusBadGateway,
+				FailureReason: metrics.FailureReasonOther,
+				Retriable:     true,
+			},
+		},
+		{
+			name: "network error",
+			err:  net.UnknownNetworkError("network error"),
+			expected: PushError{
+				FailureReason: metrics.FailureReasonNetwork,
+				Retriable:     true,
+			},
+		},
+		{
+			name: "canceled",
+			err:  fmt.Errorf("request failed: %w", context.Canceled),
+			expected: PushError{
+				FailureReason: metrics.FailureReasonCanceled,
+			},
+			expectedIsErr: context.Canceled,
+		},
+		{
+			name: "too large",
+			err:  deckerrors.ConfigTooLargeError{Size: 2, Limit: 1},
+			expected: PushError{
+				FailureReason: metrics.FailureReasonTooLarge,
+			},
+			expectedIsErr: deckerrors.ConfigTooLargeError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pushErr := newPushError(tc.err)
+			tc.expected.Err = tc.err
+			require.Equal(t, tc.expected, pushErr)
+			require.Equal(t, tc.err, errors.Unwrap(pushErr))
+			if tc.expectedIsErr != nil {
+				require.ErrorIs(t, pushErr, tc.expectedIsErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// UpdateSkippedDueToRateLimitError is returned from PerformUpdate when the configuration push was skipped due to
+// exceeding the push rate limit of the data-plane (see PushRateLimiter).
+type UpdateSkippedDueToRateLimitError struct {
+	// RetryAfter is the time after which a push would be allowed.
+	RetryAfter time.Duration
+}
+
+func (e UpdateSkippedDueToRateLimitError) Error() string {
+	return fmt.Sprintf("update skipped due to exceeding the push rate limit, retry after %s", e.RetryAfter)
+}
+
+// PushRateLimiter limits the rate of configuration pushes to every data-plane with a token bucket, so that a fragile
+// Admin API isn't overwhelmed with pushes (e.g. when the configuration changes frequently). A push exceeding
+// the limit waits for its turn up to a maximum wait time (and the deadline of the context passed to PerformUpdate),
+// failing with UpdateSkippedDueToRateLimitError if it can't be made in time.
+// Only actual pushes consume tokens: syncs skipped due to no configuration change (or for any other reason) don't.
+// A single PushRateLimiter is meant to be shared by all the Kong instances, each having its own bucket.
+// A nil PushRateLimiter doesn't limit pushes.
+type PushRateLimiter struct {
+	limit   rate.Limit
+	burst   int
+	maxWait time.Duration
+
+	lock     sync.Mutex
+	limiters map[string]*rate.Limiter // Keyed by data-plane URL.
+}
+
+// NewPushRateLimiter returns a PushRateLimiter allowing pushesPerSecond pushes per second to every data-plane with
+// bursts of up to burst pushes. Pushes exceeding the limit wait up to maxWait (zero makes them fail right away).
+func NewPushRateLimiter(pushesPerSecond float64, burst int, maxWait time.Duration) *PushRateLimiter {
+	return &PushRateLimiter{
+		limit:    rate.Limit(pushesPerSecond),
+		burst:    max(burst, 1),
+		maxWait:  maxWait,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// wait blocks until a push to the data-plane is allowed. It fails with UpdateSkippedDueToRateLimitError without
+// waiting if the push wouldn't be allowed within the maximum wait time or before the context's deadline.
+func (l *PushRateLimiter) wait(ctx context.Context, dataplane string) error {
+	if l == nil {
+		return nil
+	}
+
+	reservation := l.limiter(dataplane).Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	maxWait := l.maxWait
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = min(maxWait, time.Until(deadline))
+	}
+	if delay > maxWait {
+		// Token is given back as the push is not made.
+		reservation.Cancel()
+		return UpdateSkippedDueToRateLimitError{RetryAfter: delay}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+func (l *PushRateLimiter) limiter(dataplane string) *rate.Limiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limiter, ok := l.limiters[dataplane]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[dataplane] = limiter
+	}
+	return limiter
+}
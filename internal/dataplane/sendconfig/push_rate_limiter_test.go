@@ -0,0 +1,75 @@
+package sendconfig_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+func TestPerformUpdate_PushRateLimiter(t *testing.T) {
+	performUpdate := func(
+		t *testing.T, limiter *sendconfig.PushRateLimiter, promMetrics *metrics.CtrlFuncMetrics, hasChanged bool,
+	) (*diffReportingUpdateStrategy, error) {
+		strategy := &diffReportingUpdateStrategy{}
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t),
+			sendconfig.Config{PushRateLimiter: limiter}, testContent(), promMetrics,
+			staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: hasChanged},
+		)
+		return strategy, err
+	}
+
+	t.Run("push exceeding the limit is skipped", func(t *testing.T) {
+		promMetrics := metrics.NewCtrlFuncMetrics()
+		limiter := sendconfig.NewPushRateLimiter(0.001, 1, 0)
+
+		strategy, err := performUpdate(t, limiter, promMetrics, true)
+		require.NoError(t, err)
+		require.True(t, strategy.wasCalled)
+
+		strategy, err = performUpdate(t, limiter, promMetrics, true)
+		var rateLimitErr sendconfig.UpdateSkippedDueToRateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		require.Positive(t, rateLimitErr.RetryAfter)
+		require.False(t, strategy.wasCalled)
+		require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigPushRateLimitedCount.WithLabelValues(mustTestClient(t).BaseRootURL())))
+	})
+
+	t.Run("push exceeding the limit waits up to the max wait time", func(t *testing.T) {
+		limiter := sendconfig.NewPushRateLimiter(20, 1, time.Second)
+
+		_, err := performUpdate(t, limiter, nil, true)
+		require.NoError(t, err)
+
+		start := time.Now()
+		strategy, err := performUpdate(t, limiter, nil, true)
+		require.NoError(t, err)
+		require.True(t, strategy.wasCalled)
+		require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("skipped syncs don't consume tokens", func(t *testing.T) {
+		limiter := sendconfig.NewPushRateLimiter(0.001, 1, 0)
+
+		for i := 0; i < 3; i++ {
+			_, err := performUpdate(t, limiter, nil, false)
+			require.NoError(t, err)
+		}
+		strategy, err := performUpdate(t, limiter, nil, true)
+		require.NoError(t, err)
+		require.True(t, strategy.wasCalled)
+	})
+
+	t.Run("nil limiter doesn't limit pushes", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := performUpdate(t, nil, nil, true)
+			require.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package sendconfig
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// AdaptivePushTimeout computes timeouts of configuration pushes to a data-plane from durations of its recent pushes
+// (their 95th percentile multiplied by a factor, bounded by a floor and a ceiling), so that pushes to a data-plane
+// that has become slower (e.g. due to a growing configuration) don't time out, while stuck pushes are still cut off.
+// A nil AdaptivePushTimeout computes no timeouts.
+type AdaptivePushTimeout struct {
+	multiplier float64
+	floor      time.Duration
+	ceiling    time.Duration
+	windowSize int
+
+	lock      sync.Mutex
+	durations map[string][]time.Duration // Recent push durations (oldest first) keyed by data-plane URL.
+}
+
+// NewAdaptivePushTimeout returns an AdaptivePushTimeout setting timeouts to the multiplier of the 95th percentile
+// of the last windowSize push durations, bounded by floor and ceiling. The ceiling is used until a push duration
+// is observed.
+func NewAdaptivePushTimeout(multiplier float64, floor, ceiling time.Duration, windowSize int) *AdaptivePushTimeout {
+	return &AdaptivePushTimeout{
+		multiplier: multiplier,
+		floor:      floor,
+		ceiling:    max(floor, ceiling),
+		windowSize: max(windowSize, 1),
+		durations:  map[string][]time.Duration{},
+	}
+}
+
+// Timeout returns the timeout of the next push to the data-plane. False is returned when it's nil.
+func (t *AdaptivePushTimeout) Timeout(dataplane string) (time.Duration, bool) {
+	if t == nil {
+		return 0, false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	durations := t.durations[dataplane]
+	if len(durations) == 0 {
+		return t.ceiling, true
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	p95 := sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+	timeout := time.Duration(t.multiplier * float64(p95))
+	return min(max(timeout, t.floor), t.ceiling), true
+}
+
+// Observe records a duration of a push to the data-plane. It should be called for successful pushes and for pushes
+// that timed out (their actual duration being at least the timeout), so that the timeout grows when pushes become
+// consistently slower than it.
+func (t *AdaptivePushTimeout) Observe(dataplane string, d time.Duration) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	durations := append(t.durations[dataplane], d)
+	if len(durations) > t.windowSize {
+		durations = durations[len(durations)-t.windowSize:]
+	}
+	t.durations[dataplane] = durations
+}
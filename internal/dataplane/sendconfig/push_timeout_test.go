@@ -0,0 +1,91 @@
+package sendconfig_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+func TestAdaptivePushTimeout(t *testing.T) {
+	const dataplane = "https://kong:8444"
+
+	t.Run("ceiling is used until a push is observed", func(t *testing.T) {
+		a := sendconfig.NewAdaptivePushTimeout(3, time.Second, time.Minute, 20)
+		timeout, ok := a.Timeout(dataplane)
+		require.True(t, ok)
+		require.Equal(t, time.Minute, timeout)
+	})
+
+	t.Run("timeout is a multiple of the 95th percentile", func(t *testing.T) {
+		a := sendconfig.NewAdaptivePushTimeout(3, time.Second, time.Minute, 20)
+		for i := 1; i <= 20; i++ {
+			a.Observe(dataplane, time.Duration(i)*time.Second/2)
+		}
+		timeout, _ := a.Timeout(dataplane)
+		require.Equal(t, 3*(19*time.Second/2), timeout, "a single outlier shouldn't affect the timeout")
+
+		timeout, _ = a.Timeout("https://other-kong:8444")
+		require.Equal(t, time.Minute, timeout, "durations should be tracked per data-plane")
+	})
+
+	t.Run("timeout is bounded", func(t *testing.T) {
+		a := sendconfig.NewAdaptivePushTimeout(3, time.Second, time.Minute, 20)
+		a.Observe(dataplane, time.Millisecond)
+		timeout, _ := a.Timeout(dataplane)
+		require.Equal(t, time.Second, timeout)
+
+		a.Observe(dataplane, time.Hour)
+		timeout, _ = a.Timeout(dataplane)
+		require.Equal(t, time.Minute, timeout)
+	})
+
+	t.Run("only recent pushes are taken into account", func(t *testing.T) {
+		a := sendconfig.NewAdaptivePushTimeout(2, time.Millisecond, time.Minute, 3)
+		a.Observe(dataplane, 10*time.Second)
+		for i := 0; i < 3; i++ {
+			a.Observe(dataplane, time.Second)
+		}
+		timeout, _ := a.Timeout(dataplane)
+		require.Equal(t, 2*time.Second, timeout)
+	})
+
+	t.Run("nil timeout is a no-op", func(t *testing.T) {
+		var a *sendconfig.AdaptivePushTimeout
+		a.Observe(dataplane, time.Second)
+		_, ok := a.Timeout(dataplane)
+		require.False(t, ok)
+	})
+}
+
+func TestPerformUpdate_AdaptivePushTimeout(t *testing.T) {
+	client := mustTestClient(t)
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	adaptiveTimeout := sendconfig.NewAdaptivePushTimeout(2, 10*time.Millisecond, 20*time.Millisecond, 10)
+	config := sendconfig.Config{PushTimeout: time.Hour, AdaptivePushTimeout: adaptiveTimeout}
+
+	start := time.Now()
+	result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(), promMetrics,
+		staticUpdateStrategyResolver{strategy: canceledUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.Error(t, err)
+	require.Equal(t, metrics.FailureReasonTimeout, result.FailureReason)
+	require.Less(t, time.Since(start), time.Hour, "adaptive timeout should take precedence over the fixed one")
+	require.Equal(t, float64(20), testutil.ToFloat64(promMetrics.ConfigPushTimeout.WithLabelValues(client.BaseRootURL())))
+
+	timeout, _ := adaptiveTimeout.Timeout(client.BaseRootURL())
+	require.Equal(t, 20*time.Millisecond, timeout, "timed out push should be observed")
+
+	_, _, err = sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(), promMetrics,
+		staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	timeout, _ = adaptiveTimeout.Timeout(client.BaseRootURL())
+	require.Equal(t, 20*time.Millisecond, timeout, "95th percentile should include the timed out push")
+}
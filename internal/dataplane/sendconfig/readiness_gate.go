@@ -0,0 +1,26 @@
+package sendconfig
+
+import (
+	"time"
+)
+
+// ReadinessGate tells whether configuration can be pushed to Kong. It's meant to hold pushes back until
+// the configuration is complete (e.g. until caches of Kubernetes resources are warm), so Kong doesn't get
+// a partial configuration (e.g. right after the controller's startup) that is immediately replaced.
+type ReadinessGate func() bool
+
+// NewSettleDelayReadinessGate returns a ReadinessGate that opens once the delay has elapsed since its creation.
+func NewSettleDelayReadinessGate(delay time.Duration) ReadinessGate {
+	readyAt := time.Now().Add(delay)
+	return func() bool {
+		return !time.Now().Before(readyAt)
+	}
+}
+
+// UpdateSkippedDueToReadinessGateError is returned from PerformUpdate when the configuration push was skipped
+// due to Config.ReadinessGate not being open yet.
+type UpdateSkippedDueToReadinessGateError struct{}
+
+func (e UpdateSkippedDueToReadinessGateError) Error() string {
+	return "update skipped due to the readiness gate not being open yet"
+}
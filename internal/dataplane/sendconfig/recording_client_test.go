@@ -0,0 +1,149 @@
+package sendconfig_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/versions"
+)
+
+// recordedOperation is an Admin API request modifying a single entity, as made by deck's syncer in DB mode.
+type recordedOperation struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+// recordingTransport is an http.RoundTripper serving Admin API requests with a handler (without a network round trip)
+// and recording what was pushed to Kong, so that tests can assert on what PerformUpdate sent. Configurations posted
+// to /config (DB-less mode) and requests modifying entities (DB mode) are recorded.
+type recordingTransport struct {
+	t       *testing.T
+	handler http.Handler
+
+	lock       sync.Mutex
+	configs    []map[string]any
+	operations []recordedOperation
+}
+
+// newRecordingClient returns a client whose requests are served by the handler and recorded by the returned transport.
+func newRecordingClient(t *testing.T, handler http.Handler) (*adminapi.Client, *recordingTransport) {
+	transport := &recordingTransport{t: t, handler: handler}
+	kongClient, err := kong.NewClient(kong.String("http://kong.recording:8001"), &http.Client{Transport: transport})
+	require.NoError(t, err)
+	return adminapi.NewClient(kongClient), transport
+}
+
+// RoundTrip satisfies the RoundTripper interface.
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		require.NoError(rt.t, err)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if req.Method != http.MethodGet {
+		rt.record(req, body)
+	}
+
+	rec := httptest.NewRecorder()
+	rt.handler.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+func (rt *recordingTransport) record(req *http.Request, body []byte) {
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		r, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(rt.t, err)
+		body, err = io.ReadAll(r)
+		require.NoError(rt.t, err)
+	}
+	decoded := map[string]any{}
+	if len(body) > 0 {
+		require.NoError(rt.t, json.Unmarshal(body, &decoded))
+	}
+
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	if req.Method == http.MethodPost && strings.TrimSuffix(req.URL.Path, "/") == "/config" {
+		rt.configs = append(rt.configs, decoded)
+		return
+	}
+	rt.operations = append(rt.operations, recordedOperation{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Body:   decoded,
+	})
+}
+
+// pushedConfigs returns decoded configurations posted to /config in the order they were pushed.
+func (rt *recordingTransport) pushedConfigs() []map[string]any {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	return append([]map[string]any{}, rt.configs...)
+}
+
+// recordedOperations returns requests modifying entities in the order they were made.
+func (rt *recordingTransport) recordedOperations() []recordedOperation {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	return append([]recordedOperation{}, rt.operations...)
+}
+
+func TestPerformUpdate_RecordingClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("DB-less mode", func(t *testing.T) {
+		client, recorder := newRecordingClient(t, newFakeAdminAPIHandler(t, 0))
+		config := sendconfig.Config{InMemory: true}
+
+		_, _, err := sendconfig.PerformUpdate(
+			ctx, logr.Discard(), client, config, testContent(), metrics.NewCtrlFuncMetrics(),
+			sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()), staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+
+		configs := recorder.pushedConfigs()
+		require.Len(t, configs, 1)
+		services, ok := configs[0]["services"].([]any)
+		require.True(t, ok)
+		require.Len(t, services, 1)
+		require.Equal(t, "service", services[0].(map[string]any)["name"])
+		require.Empty(t, recorder.recordedOperations())
+	})
+
+	t.Run("DB mode", func(t *testing.T) {
+		client, recorder := newRecordingClient(t, newFakeAdminAPIHandler(t, 0))
+		config := sendconfig.Config{Version: versions.KICv3VersionCutoff, Concurrency: 1}
+
+		_, _, err := sendconfig.PerformUpdate(
+			ctx, logr.Discard(), client, config, testContent(), metrics.NewCtrlFuncMetrics(),
+			sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()), staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+
+		operations := recorder.recordedOperations()
+		require.Len(t, operations, 1)
+		require.Equal(t, http.MethodPut, operations[0].Method)
+		require.True(t, strings.HasPrefix(operations[0].Path, "/services/"))
+		require.Equal(t, "service", operations[0].Body["name"])
+		require.Empty(t, recorder.pushedConfigs())
+	})
+}
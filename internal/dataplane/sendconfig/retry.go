@@ -0,0 +1,127 @@
+package sendconfig
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// RetryPolicy configures how configuration pushes failing due to transient errors are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of push attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts uint
+
+	// BaseDelay is the delay before the first retry. It's doubled with every subsequent retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between retries. Zero means no cap.
+	MaxDelay time.Duration
+
+	// UnavailableDelay is the minimum delay before retrying a push that failed due to the Admin API being unavailable
+	// (503 Service Unavailable), giving Kong time to come back e.g. when it's restarting during a rolling upgrade.
+	UnavailableDelay time.Duration
+}
+
+func (p RetryPolicy) enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// delayForAttempt returns the delay to wait before the retry following the given (1-based) attempt.
+// It's capped at MaxDelay (or the maximum time.Duration when it's not set) so that it doesn't overflow.
+func (p RetryPolicy) delayForAttempt(attempt uint) time.Duration {
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64
+	}
+	delay := p.BaseDelay
+	for i := uint(1); i < attempt && delay > 0 && delay < maxDelay; i++ {
+		if delay > maxDelay/2 {
+			return maxDelay
+		}
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// delayForError returns the delay to wait before the retry following the given (1-based) attempt failing with err.
+func (p RetryPolicy) delayForError(attempt uint, err error) time.Duration {
+	delay := p.delayForAttempt(attempt)
+	if deckerrors.IsUnavailableErr(err) {
+		return max(delay, p.UnavailableDelay)
+	}
+	return delay
+}
+
+// updateWithRetry calls UpdateStrategy.Update, retrying it according to the passed RetryPolicy in case
+// it fails with a retriable error. onRetry is called before every retry attempt.
+// Results of the last attempt are returned.
+func updateWithRetry(
+	ctx context.Context,
+	logger logr.Logger,
+	updateStrategy UpdateStrategy,
+	targetContent ContentWithHash,
+	policy RetryPolicy,
+	onRetry func(),
+) (
+	stats UpdateStats,
+	err error,
+	resourceErrors []ResourceError,
+	resourceErrorsParseErr error,
+) {
+	for attempt := uint(1); ; attempt++ {
+		stats, err, resourceErrors, resourceErrorsParseErr = updateStrategy.Update(ctx, targetContent)
+		if err == nil || !policy.enabled() || attempt >= policy.MaxAttempts || !isRetriableUpdateError(err) {
+			return stats, err, resourceErrors, resourceErrorsParseErr
+		}
+
+		delay := policy.delayForError(attempt, err)
+		if deckerrors.IsUnavailableErr(err) {
+			logger.V(util.DebugLevel).Info("Kong Admin API is unavailable, waiting for it to come back before retrying",
+				"attempt", attempt, "delay", delay, "reason", err.Error(),
+			)
+		} else {
+			logger.V(util.DebugLevel).Info("Update failed with a retriable error, retrying",
+				"attempt", attempt, "delay", delay, "reason", err.Error(),
+			)
+		}
+		onRetry()
+
+		select {
+		case <-ctx.Done():
+			return stats, err, resourceErrors, resourceErrorsParseErr
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isRetriableUpdateError tells whether an update error is transient and the update may succeed when retried.
+// Only network errors and server-side Admin API errors (5xx, including the Admin API being unavailable in DB-less mode)
+// are considered retriable. Conflicts are never retried as they won't resolve without a configuration change.
+func isRetriableUpdateError(err error) bool {
+	if errors.As(err, &UpdateSkippedDueToBackoffStrategyError{}) || deckerrors.IsConflictErr(err) {
+		return false
+	}
+
+	if deckerrors.IsUnavailableErr(err) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return lo.ContainsBy(deckerrors.StatusCodes(err), func(code int) bool {
+		return code >= http.StatusInternalServerError
+	})
+}
@@ -0,0 +1,198 @@
+package sendconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	deckutils "github.com/kong/deck/utils"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+// failingUpdateStrategy fails the first failuresCount updates with err.
+type failingUpdateStrategy struct {
+	err           error
+	failuresCount int
+	calls         int
+}
+
+func (s *failingUpdateStrategy) Update(context.Context, ContentWithHash) (UpdateStats, error, []ResourceError, error) {
+	s.calls++
+	if s.calls <= s.failuresCount {
+		return UpdateStats{}, s.err, nil, nil
+	}
+	return UpdateStats{}, nil, nil, nil
+}
+
+func (s *failingUpdateStrategy) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolDBLess
+}
+
+func (s *failingUpdateStrategy) Type() string {
+	return "Failing"
+}
+
+func TestUpdateWithRetry(t *testing.T) {
+	var (
+		networkErr  = net.UnknownNetworkError("network error")
+		serverErr   = kong.NewAPIError(http.StatusInternalServerError, "internal error")
+		conflictErr = kong.NewAPIError(http.StatusConflict, "conflict")
+		policy      = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	)
+
+	testCases := []struct {
+		name            string
+		err             error
+		failuresCount   int
+		policy          RetryPolicy
+		expectedCalls   int
+		expectedRetries int
+		expectError     bool
+	}{
+		{
+			name:          "success on first attempt",
+			policy:        policy,
+			expectedCalls: 1,
+		},
+		{
+			name:            "network error is retried until success",
+			err:             networkErr,
+			failuresCount:   2,
+			policy:          policy,
+			expectedCalls:   3,
+			expectedRetries: 2,
+		},
+		{
+			name:            "server error wrapped in deck error array is retried",
+			err:             deckutils.ErrArray{Errors: []error{serverErr}},
+			failuresCount:   1,
+			policy:          policy,
+			expectedCalls:   2,
+			expectedRetries: 1,
+		},
+		{
+			name:            "service unavailable error is retried",
+			err:             kong.NewAPIError(http.StatusServiceUnavailable, "service unavailable"),
+			failuresCount:   2,
+			policy:          policy,
+			expectedCalls:   3,
+			expectedRetries: 2,
+		},
+		{
+			name:            "DB-less admin API unavailable error is retried",
+			err:             deckerrors.ConfigStatusError{StatusCode: http.StatusServiceUnavailable, Err: errors.New("got status code 503")},
+			failuresCount:   1,
+			policy:          policy,
+			expectedCalls:   2,
+			expectedRetries: 1,
+		},
+		{
+			name:            "DB-less configuration push server error is retried",
+			err:             deckerrors.ConfigStatusError{StatusCode: http.StatusInternalServerError, Err: errors.New("got status code 500")},
+			failuresCount:   1,
+			policy:          policy,
+			expectedCalls:   2,
+			expectedRetries: 1,
+		},
+		{
+			name:            "retries stop after max attempts",
+			err:             networkErr,
+			failuresCount:   5,
+			policy:          policy,
+			expectedCalls:   3,
+			expectedRetries: 2,
+			expectError:     true,
+		},
+		{
+			name:          "conflict is not retried",
+			err:           conflictErr,
+			failuresCount: 1,
+			policy:        policy,
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name:          "generic error is not retried",
+			err:           errors.New("generic error"),
+			failuresCount: 1,
+			policy:        policy,
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name:          "retries disabled by default",
+			err:           networkErr,
+			failuresCount: 1,
+			expectedCalls: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy := &failingUpdateStrategy{err: tc.err, failuresCount: tc.failuresCount}
+			retries := 0
+
+			_, err, _, _ := updateWithRetry(context.Background(), logr.Discard(), strategy, ContentWithHash{}, tc.policy, func() {
+				retries++
+			})
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedCalls, strategy.calls)
+			require.Equal(t, tc.expectedRetries, retries)
+		})
+	}
+}
+
+func TestRetryPolicy_DelayForAttempt(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, BaseDelay: time.Second}
+	for attempt, expected := range map[uint]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+	} {
+		require.Equal(t, expected, policy.delayForAttempt(attempt), fmt.Sprintf("attempt %d", attempt))
+	}
+}
+
+func TestRetryPolicy_DelayForAttemptMaxDelay(t *testing.T) {
+	t.Run("delay is capped", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+		require.Equal(t, 4*time.Second, policy.delayForAttempt(3))
+		require.Equal(t, 5*time.Second, policy.delayForAttempt(4))
+		require.Equal(t, 5*time.Second, policy.delayForAttempt(10))
+	})
+
+	t.Run("base delay exceeding cap", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Minute, MaxDelay: time.Second}
+		require.Equal(t, time.Second, policy.delayForAttempt(1))
+	})
+
+	t.Run("delay doesn't overflow without cap", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 1000, BaseDelay: time.Second}
+		require.Equal(t, time.Duration(math.MaxInt64), policy.delayForAttempt(100))
+		require.Equal(t, time.Duration(math.MaxInt64), policy.delayForAttempt(1000))
+	})
+}
+
+func TestRetryPolicy_DelayForError(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, BaseDelay: time.Second, UnavailableDelay: 3 * time.Second}
+	unavailableErr := kong.NewAPIError(http.StatusServiceUnavailable, "service unavailable")
+
+	require.Equal(t, time.Second, policy.delayForError(1, net.UnknownNetworkError("network error")))
+	require.Equal(t, 3*time.Second, policy.delayForError(1, unavailableErr), "unavailable delay should be the minimum")
+	require.Equal(t, 4*time.Second, policy.delayForError(3, unavailableErr), "longer backoff delay should be kept")
+}
@@ -1,17 +1,24 @@
 package sendconfig
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/uuid"
 	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/mo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
@@ -35,9 +42,71 @@ type AdminAPIClient interface {
 
 	IsKonnect() bool
 	KonnectControlPlane() string
+
+	// PushLock returns the lock serializing configuration pushes to the Admin API.
+	PushLock() sync.Locker
+}
+
+// UpdateResult describes the outcome of PerformUpdate.
+type UpdateResult struct {
+	// ConfigSHA is the hash of the configuration that the data-plane holds after the update.
+	ConfigSHA []byte
+
+	// Diff summarizes changes made (or in the dry run mode, see Config.DryRun, changes that would be made)
+	// to the data-plane's configuration. It's populated only when the changes are known, i.e. in DB mode,
+	// in the dry run mode, or when the update was skipped due to no configuration change (an empty summary).
+	Diff mo.Option[DiffSummary]
+
+	// Pushed tells whether the configuration was pushed to the data-plane. It's false when the push was skipped
+	// (e.g. due to no configuration change or in the dry run mode).
+	Pushed bool
+
+	// Duration is the time spent on pushing the configuration (including retries). It's zero when no push
+	// was attempted.
+	Duration time.Duration
+
+	// Protocol is the protocol the configuration was pushed with. It's empty when no push was attempted.
+	Protocol metrics.Protocol
+
+	// FailureReason is the reason of the push failure (one of metrics.FailureReason* constants). It's empty
+	// unless the push failed.
+	FailureReason string
+
+	// EntityFailures are entities that failed to be synced in DB mode (see PartialUpdateError). Changes other than
+	// the failed ones are applied regardless (and summarized in Diff) unless they were rolled back. As Kong's
+	// configuration doesn't match the pushed one then, ConfigSHA is not set, so that the next update pushes
+	// the configuration again.
+	EntityFailures []EntityFailure
+
+	// SyncID uniquely identifies the sync (see ContextWithSyncID). All the lines PerformUpdate logs have it
+	// in the sync_id field.
+	SyncID string
+}
+
+// UpdateCanceledError is returned from PerformUpdate when the configuration push was aborted due to the context
+// being canceled (e.g. on the controller's shutdown). Changes made before the cancellation are not rolled back.
+// It matches context.Canceled when checked with errors.Is.
+type UpdateCanceledError struct {
+	Err error
+}
+
+func (e UpdateCanceledError) Error() string {
+	return fmt.Sprintf("configuration update canceled: %v", e.Err)
+}
+
+func (e UpdateCanceledError) Is(target error) bool {
+	return errors.Is(target, context.Canceled)
+}
+
+func (e UpdateCanceledError) Unwrap() error {
+	return e.Err
 }
 
 // PerformUpdate writes `targetContent` to Kong Admin API specified by `kongConfig`.
+// In case Config.DryRun is set, no changes are made and UpdateResult.Diff contains changes that would be made.
+// Concurrent calls for the same client are serialized. Failed pushes are reported with PushError.
+// Every call is identified by a sync ID (see UpdateResult.SyncID) that is taken from the context (see
+// ContextWithSyncID) or generated.
 func PerformUpdate(
 	ctx context.Context,
 	logger logr.Logger,
@@ -47,59 +116,373 @@ func PerformUpdate(
 	promMetrics *metrics.CtrlFuncMetrics,
 	updateStrategyResolver UpdateStrategyResolver,
 	configChangeDetector ConfigurationChangeDetector,
-) ([]byte, []failures.ResourceFailure, error) {
+) (UpdateResult, []failures.ResourceFailure, error) {
+	syncID, ok := SyncIDFromContext(ctx)
+	if !ok {
+		syncID = uuid.NewString()
+		ctx = ContextWithSyncID(ctx, syncID)
+	}
+	result, resourceFailures, err := performUpdate(
+		ctx, logger.WithValues("sync_id", syncID), client, config, targetContent, promMetrics,
+		updateStrategyResolver, configChangeDetector,
+	)
+	result.SyncID = syncID
+	return result, resourceFailures, err
+}
+
+func performUpdate(
+	ctx context.Context,
+	logger logr.Logger,
+	client AdminAPIClient,
+	config Config,
+	targetContent *file.Content,
+	promMetrics *metrics.CtrlFuncMetrics,
+	updateStrategyResolver UpdateStrategyResolver,
+	configChangeDetector ConfigurationChangeDetector,
+) (UpdateResult, []failures.ResourceFailure, error) {
+	// Pushes to the same Kong instance are serialized, so that they don't interleave and each of them sees
+	// the configuration SHA stored by the previous one.
+	ticket := config.PushCoalescer.request(client.BaseRootURL())
+	pushLock := client.PushLock()
+	pushLock.Lock()
+	defer pushLock.Unlock()
+
 	oldSHA := client.LastConfigSHA()
-	newSHA, err := deckgen.GenerateSHA(targetContent)
+
+	if err := config.PushCoalescer.superseded(client.BaseRootURL(), ticket); err != nil {
+		logger.V(util.DebugLevel).Info("Configuration push superseded by a newer one, skipping it")
+		promMetrics.RecordConfigPushCoalesced(client.BaseRootURL())
+		return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
+	}
+
+	if config.ReadinessGate != nil && !config.ReadinessGate() {
+		logger.V(util.DebugLevel).Info("Readiness gate is not open yet, skipping configuration push")
+		return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, UpdateSkippedDueToReadinessGateError{}
+	}
+
+	if correlationID, ok := adminapi.CorrelationIDFromContext(ctx); ok {
+		logger = logger.WithValues("correlation_id", correlationID)
+		// Every push tracks Kong request IDs on its own as pushes to multiple gateways may run concurrently.
+		ctx = adminapi.ContextWithCorrelationID(ctx, correlationID)
+	}
+
+	preparationStart := time.Now()
+	if err := transformContent(targetContent, config.ContentTransformers); err != nil {
+		protocol := updateStrategyResolver.ResolveUpdateStrategy(client).MetricsProtocol()
+		promMetrics.RecordPushFailure(protocol, 0, client.BaseRootURL(), 0, err)
+		pushErr := newPushError(err)
+		return UpdateResult{ConfigSHA: oldSHA, Protocol: protocol, FailureReason: pushErr.FailureReason},
+			[]failures.ResourceFailure{}, pushErr
+	}
+
+	scoped := len(config.SyncScopeTags) > 0
+	if scoped {
+		if config.InMemory && !client.IsKonnect() {
+			return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, errors.New("configuration push scoped by tags is supported only in DB mode")
+		}
+		targetContent = deckgen.ScopeContentByTags(targetContent, config.SyncScopeTags)
+	}
+	if len(config.ReverseSyncEntityTypes) > 0 && config.InMemory && !client.IsKonnect() {
+		return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, errors.New("reverse sync of selected entity types is supported only in DB mode")
+	}
+
+	fingerprint := deckgen.GenerateSHA
+	if config.Fingerprinter != nil {
+		fingerprint = config.Fingerprinter
+	}
+	newSHA, err := fingerprint(targetContent)
 	if err != nil {
-		return oldSHA, []failures.ResourceFailure{}, err
+		return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
+	}
+	preparationDuration := time.Since(preparationStart)
+	// Fields attached to all the following log lines. Their names should be kept stable as log parsers rely on them.
+	logger = logger.WithValues("config_sha", hex.EncodeToString(newSHA), "entities", deckgen.CountEntities(targetContent))
+	logGeneratedConfig(logger, config, targetContent)
+	if config.CheckPluginOrdering {
+		checkPluginOrdering(logger, targetContent)
 	}
 
-	// disable optimization if reverse sync is enabled
-	if !config.EnableReverseSync {
-		configurationChanged, err := configChangeDetector.HasConfigurationChanged(ctx, oldSHA, newSHA, targetContent, client, client.AdminAPIClient())
+	if config.DryRun {
+		// The decK diff is used regardless of the data-plane's mode: DB-less Kong serves its current configuration
+		// over the same read-only Admin API endpoints decK dumps, and the diff is solved without sending any writes.
+		diff, err := newUpdateStrategyDBModeForClient(client, config, logger).Diff(ctx, targetContent)
 		if err != nil {
-			return nil, []failures.ResourceFailure{}, err
+			return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, fmt.Errorf("computing diff: %w", err)
 		}
-		if !configurationChanged {
-			if client.IsKonnect() {
-				logger.V(util.DebugLevel).Info("No configuration change, skipping sync to Konnect")
-			} else {
-				logger.V(util.DebugLevel).Info("No configuration change, skipping sync to Kong")
+		logger.V(util.DebugLevel).Info("Computed configuration diff in dry run mode",
+			"creating", diff.Creating, "updating", diff.Updating, "deleting", diff.Deleting,
+		)
+		return UpdateResult{ConfigSHA: oldSHA, Diff: mo.Some(diff)}, []failures.ResourceFailure{}, nil
+	}
+
+	if err := config.SyncPause.skip(client.BaseRootURL(), newSHA); err != nil {
+		logger.V(util.DebugLevel).Info("Configuration sync is paused, skipping configuration push")
+		promMetrics.RecordConfigSyncPaused(client.BaseRootURL())
+		return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
+	}
+	// Configuration that was pending during the pause is pushed in full, regardless of its SHA.
+	resumed := !scoped && config.SyncPause.resumed(client.BaseRootURL())
+
+	// reverseSyncOnly is set when the configuration hasn't changed, but entities of selected types have to be synced.
+	var reverseSyncOnly bool
+
+	forcedResync := !config.EnableReverseSync && !scoped && !resumed && config.ForcedResync.Due(client.BaseRootURL())
+	if forcedResync {
+		logger.V(util.InfoLevel).Info("Forcing periodic configuration resync")
+		promMetrics.RecordConfigForcedResync(client.BaseRootURL())
+	}
+
+	// disable optimization if reverse sync is enabled, a resync is forced, the sync has been resumed after a pause
+	// or the push is scoped (last config SHA is tracked for full configurations only)
+	if !config.EnableReverseSync && !scoped && !forcedResync && !resumed {
+		// Trust the SHAs equality without verifying Kong's configuration hash if configured so.
+		configurationChange := ConfigurationUnchanged
+		if !config.SkipStatusCheckOnEqualSHA || !bytes.Equal(oldSHA, newSHA) {
+			configurationChange, err = configChangeDetector.HasConfigurationChanged(ctx, oldSHA, newSHA, targetContent, client, client.AdminAPIClient())
+			if err != nil {
+				return UpdateResult{}, []failures.ResourceFailure{}, err
+			}
+			switch configurationChange {
+			case ConfigurationChangedInitialHash:
+				// The data-plane reported the initial hash after a crash or restart, forcing a full re-sync.
+				if !config.InitialHashGracePeriod.Elapsed(client.BaseRootURL()) {
+					logger.V(util.DebugLevel).Info("Data-plane reported no configuration, " +
+						"deferring configuration re-sync until the grace period elapses")
+					promMetrics.RecordConfigHashInitialGrace(client.BaseRootURL())
+					return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, nil
+				}
+				logger.V(util.DebugLevel).Info("Data-plane reported no configuration, forcing configuration re-sync")
+				promMetrics.RecordConfigHashInitial(client.BaseRootURL())
+			case ConfigurationUnchanged:
+				config.InitialHashGracePeriod.Reset(client.BaseRootURL())
+			case ConfigurationChangedSHA, ConfigurationChangedStatusUnknown:
+				// The configuration is pushed right away. With an unknown status, there's no initial hash
+				// observed that the grace period could apply to.
+			}
+		}
+		if !configurationChange.Changed() {
+			if len(config.ReverseSyncEntityTypes) == 0 {
+				promMetrics.RecordPushPhaseDuration(metrics.PhasePreparation, preparationDuration, client.BaseRootURL())
+				promMetrics.RecordConfigSyncSkipped(client.BaseRootURL())
+				if config.DetectDrift && (!config.InMemory || client.IsKonnect()) {
+					checkDrift(ctx, logger, client, config, targetContent, promMetrics)
+				}
+				if config.NoConfigChangeLogThrottler.ShouldLog(client.BaseRootURL(), newSHA) {
+					if client.IsKonnect() {
+						logger.V(util.DebugLevel).Info("No configuration change, skipping sync to Konnect")
+					} else {
+						logger.V(util.DebugLevel).Info("No configuration change, skipping sync to Kong")
+					}
+				}
+				return UpdateResult{ConfigSHA: oldSHA, Diff: mo.Some(DiffSummary{})}, []failures.ResourceFailure{}, nil
+			}
+			logger.V(util.DebugLevel).Info("No configuration change, syncing entities with reverse sync enabled only",
+				"entity_types", config.ReverseSyncEntityTypes,
+			)
+			reverseSyncOnly = true
+		}
+	}
+
+	if err := config.ConflictCircuitBreaker.Allow(client.BaseRootURL()); err != nil {
+		logger.V(util.DebugLevel).Info("Circuit is open after repeated conflicts, skipping configuration push", "error", err.Error())
+		promMetrics.RecordConfigPushCircuitOpen(client.BaseRootURL())
+		return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
+	}
+
+	if !scoped && !reverseSyncOnly {
+		if err := config.EmptyConfigGuard.check(client.BaseRootURL(), targetContent); err != nil {
+			logger.Error(err, "Empty configuration guard refused configuration push")
+			return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
+		}
+	}
+
+	// Rate limit is checked past the configuration change detection, so that skipped syncs don't consume tokens.
+	if err := config.PushRateLimiter.wait(ctx, client.BaseRootURL()); err != nil {
+		if errors.As(err, &UpdateSkippedDueToRateLimitError{}) {
+			logger.V(util.DebugLevel).Info("Push rate limit exceeded, skipping configuration push", "error", err.Error())
+			promMetrics.RecordConfigPushRateLimited(client.BaseRootURL())
+		}
+		return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
+	}
+
+	if config.OnKongVersionMismatch != KongVersionMismatchActionIgnore && !client.IsKonnect() {
+		if err := checkKongVersion(ctx, client.AdminAPIClient(), config.Version); err != nil {
+			if config.OnKongVersionMismatch == KongVersionMismatchActionError {
+				return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
 			}
-			return oldSHA, []failures.ResourceFailure{}, nil
+			logger.Error(err, "Kong version check failed, pushing configuration anyway")
+		}
+	}
+
+	if config.DBModeChecker != nil && !client.IsKonnect() {
+		if err := config.DBModeChecker.Check(ctx, client.AdminAPIClient(), config.InMemory); err != nil {
+			if errors.As(err, &DBModeMismatchError{}) {
+				return UpdateResult{ConfigSHA: oldSHA}, []failures.ResourceFailure{}, err
+			}
+			logger.Error(err, "Failed to check Kong's DB mode, pushing configuration anyway")
 		}
 	}
 
 	updateStrategy := updateStrategyResolver.ResolveUpdateStrategy(client)
-	logger = logger.WithValues("update_strategy", updateStrategy.Type())
+	if scoped {
+		// Resolver is not aware of the push scope, hence the DB mode strategy is created for it explicitly.
+		updateStrategy = withClientBackoffStrategy(newUpdateStrategyDBModeForClient(client, config, logger), client, logger)
+	}
+	if reverseSyncOnly {
+		updateStrategy = withClientBackoffStrategy(
+			newUpdateStrategyDBModeForClient(client, config, logger).WithEntityTypes(config.ReverseSyncEntityTypes), client, logger,
+		)
+	}
+	metricsProtocol := updateStrategy.MetricsProtocol()
+	logger = logger.WithValues("update_strategy", updateStrategy.Type(), "protocol", string(metricsProtocol))
+	promMetrics.RecordPushProtocol(metricsProtocol, client.BaseRootURL())
+
+	if config.ValidatePluginSchemas {
+		if resourceErrors, err := validatePluginSchemas(ctx, logger, client.PluginSchemaStore(), targetContent); err != nil {
+			logger.V(util.DebugLevel).Info("Configuration failed plugin schema validation", "error", err.Error())
+			resourceFailures := resourceErrorsToResourceFailures(resourceErrors, nil, logger)
+			promMetrics.RecordPushFailure(metricsProtocol, 0, client.BaseRootURL(), len(resourceFailures), err)
+			pushErr := newPushError(err)
+			return UpdateResult{ConfigSHA: oldSHA, Protocol: metricsProtocol, FailureReason: pushErr.FailureReason},
+				resourceFailures, pushErr
+		}
+	}
+
+	if config.ValidateCertificates {
+		if resourceErrors, err := validateCertificates(logger, targetContent, time.Now()); err != nil {
+			logger.V(util.DebugLevel).Info("Configuration failed certificate validation", "error", err.Error())
+			resourceFailures := resourceErrorsToResourceFailures(resourceErrors, nil, logger)
+			promMetrics.RecordPushFailure(metricsProtocol, 0, client.BaseRootURL(), len(resourceFailures), err)
+			pushErr := newPushError(err)
+			return UpdateResult{ConfigSHA: oldSHA, Protocol: metricsProtocol, FailureReason: pushErr.FailureReason},
+				resourceFailures, pushErr
+		}
+	}
+
+	pushCtx := ctx
+	pushTimeout := config.PushTimeout
+	adaptiveTimeout, adaptive := config.AdaptivePushTimeout.Timeout(client.BaseRootURL())
+	if adaptive {
+		pushTimeout = adaptiveTimeout
+		promMetrics.RecordPushTimeout(pushTimeout, client.BaseRootURL())
+	}
+	if pushTimeout > 0 {
+		var cancel context.CancelFunc
+		pushCtx, cancel = context.WithTimeout(ctx, pushTimeout)
+		defer cancel()
+	}
+
 	timeStart := time.Now()
-	err, resourceErrors, resourceErrorsParseErr := updateStrategy.Update(ctx, ContentWithHash{
+	stats, err, resourceErrors, resourceErrorsParseErr := updateWithRetry(pushCtx, logger, updateStrategy, ContentWithHash{
 		Content: targetContent,
 		Hash:    newSHA,
+	}, config.PushRetryPolicy, func() {
+		promMetrics.RecordPushRetry(metricsProtocol, client.BaseRootURL())
 	})
 	duration := time.Since(timeStart)
+	if !errors.As(err, &UpdateSkippedDueToBackoffStrategyError{}) {
+		config.ConflictCircuitBreaker.Record(client.BaseRootURL(), err)
+	}
+	// Pushes cut off by the adaptive timeout took at least as long, letting the timeout grow when pushes become
+	// consistently slower than it.
+	if adaptive && (err == nil || (errors.Is(pushCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil)) {
+		config.AdaptivePushTimeout.Observe(client.BaseRootURL(), duration)
+	}
+	if kongRequestID, ok := adminapi.LastKongRequestIDFromContext(ctx); ok {
+		logger = logger.WithValues("kong_request_id", kongRequestID)
+	}
+	if config.SlowPushThreshold > 0 {
+		if duration <= config.SlowPushThreshold {
+			config.SlowPushLogThrottler.Reset(client.BaseRootURL())
+		} else if shouldLog, suppressed := config.SlowPushLogThrottler.ShouldLog(client.BaseRootURL()); shouldLog {
+			logger.Error(nil, "Configuration push exceeded the slow push threshold, Kong Admin API may be degraded",
+				"duration", duration, "threshold", config.SlowPushThreshold, "suppressed_warnings", suppressed,
+			)
+		}
+	}
+
+	if size, ok := stats.PayloadSize.Get(); ok {
+		promMetrics.RecordPushSize(metricsProtocol, size, client.BaseRootURL())
+	}
 
-	metricsProtocol := updateStrategy.MetricsProtocol()
 	if err != nil {
 		// Not pushing metrics in case it's an update skip due to a backoff.
 		if errors.As(err, &UpdateSkippedDueToBackoffStrategyError{}) {
-			return nil, []failures.ResourceFailure{}, err
+			return UpdateResult{}, []failures.ResourceFailure{}, err
 		}
 
+		if errors.Is(pushCtx.Err(), context.Canceled) {
+			err = UpdateCanceledError{Err: err}
+			if diff, ok := stats.Diff.Get(); ok {
+				logger.Info("Configuration update canceled, changes applied before the cancellation are kept",
+					"created", diff.Creating, "updated", diff.Updating, "deleted", diff.Deleting,
+				)
+			} else {
+				logger.Info("Configuration update canceled")
+			}
+		}
+
+		if deckerrors.IsAuthErr(err) {
+			logger.Error(err, "Kong Admin API rejected the controller's credentials, "+
+				"check the Admin API token (--kong-admin-token or --kong-admin-token-file) is valid")
+		}
+
+		if deckerrors.IsUnavailableErr(err) {
+			logger.Info("Kong Admin API is temporarily unavailable (e.g. Kong is restarting), " +
+				"configuration will be pushed again once it's back")
+		}
+
+		logger.V(util.DebugLevel).Info("Configuration push failed", "error", err.Error())
+		recordPushStats(promMetrics, preparationDuration, duration, stats, client.BaseRootURL())
 		resourceFailures := resourceErrorsToResourceFailures(resourceErrors, resourceErrorsParseErr, logger)
 		promMetrics.RecordPushFailure(metricsProtocol, duration, client.BaseRootURL(), len(resourceFailures), err)
-		return nil, resourceFailures, err
+		pushErr := newPushError(err)
+		result := UpdateResult{Duration: duration, Protocol: metricsProtocol, FailureReason: pushErr.FailureReason}
+		if partialErr := (PartialUpdateError{}); errors.As(err, &partialErr) {
+			if !partialErr.RolledBack {
+				result.Diff = mo.Some(partialErr.Applied)
+			}
+			result.EntityFailures = partialErr.Failed
+		}
+		return result, resourceFailures, pushErr
 	}
 
+	recordPushStats(promMetrics, preparationDuration, duration, stats, client.BaseRootURL())
 	promMetrics.RecordPushSuccess(metricsProtocol, duration, client.BaseRootURL())
+	config.PushDurationAverage.Observe(client.BaseRootURL(), duration)
+	if config.OnApplied != nil {
+		changed := !bytes.Equal(oldSHA, newSHA)
+		if diff, ok := stats.Diff.Get(); ok && scoped {
+			changed = diff.HasChanges()
+		}
+		config.OnApplied(newSHA, changed)
+	}
+	if !scoped && !reverseSyncOnly {
+		config.ForcedResync.Synced(client.BaseRootURL())
+		config.SyncPause.synced(client.BaseRootURL())
+	}
+	if scoped {
+		// Last config SHA and entity counts are tracked for full configurations only.
+		newSHA = oldSHA
+	} else {
+		promMetrics.RecordLastAppliedConfigSHA(newSHA, client.BaseRootURL())
+		promMetrics.RecordConfigEntityCounts(deckgen.CountEntitiesByType(targetContent), client.BaseRootURL())
+		config.EmptyConfigGuard.configApplied(client.BaseRootURL(), targetContent)
+	}
+
+	if diff, ok := stats.Diff.Get(); ok {
+		logger.V(util.DebugLevel).Info("Configuration changes applied",
+			"created", diff.Creating, "updated", diff.Updating, "deleted", diff.Deleting,
+		)
+	}
 
 	if client.IsKonnect() {
-		logger.V(util.InfoLevel).Info("Successfully synced configuration to Konnect")
+		logger.V(util.InfoLevel).Info("Successfully synced configuration to Konnect", "duration", duration)
 	} else {
-		logger.V(util.InfoLevel).Info("Successfully synced configuration to Kong")
+		logger.V(util.InfoLevel).Info("Successfully synced configuration to Kong", "duration", duration)
 	}
 
-	return newSHA, nil, nil
+	return UpdateResult{ConfigSHA: newSHA, Diff: stats.Diff, Pushed: true, Duration: duration, Protocol: metricsProtocol}, nil, nil
 }
 
 // -----------------------------------------------------------------------------
@@ -143,3 +526,33 @@ func resourceErrorsToResourceFailures(resourceErrors []ResourceError, parseErr e
 
 	return out
 }
+
+// recordPushStats records durations of the preparation and push phases of a configuration update.
+// Time spent by the update strategy on preparing the configuration is accounted to the preparation phase.
+// Other stats (e.g. time spent on syncing entities of every type) are recorded as well if the strategy reported them.
+func recordPushStats(
+	promMetrics *metrics.CtrlFuncMetrics,
+	preparationDuration time.Duration,
+	updateDuration time.Duration,
+	stats UpdateStats,
+	dataplane string,
+) {
+	promMetrics.RecordPushPhaseDuration(metrics.PhasePreparation, preparationDuration+stats.PreparationDuration, dataplane)
+	promMetrics.RecordPushPhaseDuration(metrics.PhasePush, updateDuration-stats.PreparationDuration, dataplane)
+	promMetrics.RecordPushEntityTypeDurations(stats.EntityTypeDurations, dataplane)
+	promMetrics.RecordPushQueueWaitDurations(stats.QueueWaitDurations, dataplane)
+	if wait, ok := stats.DumpWaitDuration.Get(); ok {
+		promMetrics.RecordDumpWaitDuration(wait, dataplane)
+	}
+	promMetrics.RecordPluginConfigNullsRemoved(stats.RemovedPluginConfigNulls, dataplane)
+}
+
+// transformContent runs transformers on the content, wrapping the first error returned in deckerrors.ContentTransformError.
+func transformContent(content *file.Content, transformers []ContentTransformFunc) error {
+	for _, transform := range transformers {
+		if err := transform(content); err != nil {
+			return deckerrors.ContentTransformError{Err: err}
+		}
+	}
+	return nil
+}
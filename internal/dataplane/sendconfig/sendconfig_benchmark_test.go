@@ -0,0 +1,107 @@
+package sendconfig_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/versions"
+)
+
+// handlerTransport is an http.RoundTripper serving requests with a handler without a network round trip, so that
+// benchmarks measure the controller's side of configuration pushes only.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip satisfies the RoundTripper interface.
+func (rt handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	rt.handler.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+// newFakeClient returns a client whose Admin API requests are served in-process by the handler.
+func newFakeClient(tb testing.TB, handler http.Handler) *adminapi.Client {
+	kongClient, err := kong.NewClient(kong.String("http://kong.fake:8001"), &http.Client{Transport: handlerTransport{handler: handler}})
+	require.NoError(tb, err)
+	return adminapi.NewClient(kongClient)
+}
+
+// fakeDBLessHandler is a DB-less Admin API accepting every configuration posted to /config.
+var fakeDBLessHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/config" {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}
+	_, _ = w.Write([]byte(`{}`))
+})
+
+// BenchmarkPerformUpdate measures PerformUpdate's phases with configurations of growing sizes (every service
+// generated by largeContent comes with a route, hence there are two entities per service): calculating
+// the configuration SHA, pushing it in DB-less mode (dominated by marshaling it) and syncing it in DB mode
+// (dominated by solving the diff against the current state already holding the configuration).
+func BenchmarkPerformUpdate(b *testing.B) {
+	ctx := context.Background()
+	for _, entities := range []int{100, 1000, 10000} {
+		content := largeContent(entities / 2)
+
+		b.Run(fmt.Sprintf("entities=%d/sha", entities), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := deckgen.GenerateSHA(content)
+				require.NoError(b, err)
+			}
+		})
+
+		b.Run(fmt.Sprintf("entities=%d/db-less", entities), func(b *testing.B) {
+			client := newFakeClient(b, fakeDBLessHandler)
+			config := sendconfig.Config{InMemory: true}
+			resolver := sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard())
+			benchmarkPerformUpdate(ctx, b, client, config, resolver, content)
+		})
+
+		b.Run(fmt.Sprintf("entities=%d/db-mode", entities), func(b *testing.B) {
+			client := newFakeClient(b, newFakeAdminAPIHandler(b, 0))
+			config := sendconfig.Config{Version: versions.KICv3VersionCutoff, Concurrency: 10}
+			resolver := sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard())
+
+			// Apply the configuration once so that the benchmarked syncs do not create any entities.
+			_, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), client, config, content, nil, resolver,
+				staticConfigurationChangeDetector{hasChanged: true},
+			)
+			require.NoError(b, err)
+			benchmarkPerformUpdate(ctx, b, client, config, resolver, content)
+		})
+	}
+}
+
+// benchmarkPerformUpdate runs PerformUpdate b.N times, reporting the average push duration (see UpdateResult.Duration)
+// on top of the total time per operation (including preparation of the push).
+func benchmarkPerformUpdate(
+	ctx context.Context, b *testing.B, client *adminapi.Client, config sendconfig.Config,
+	resolver sendconfig.UpdateStrategyResolver, content *file.Content,
+) {
+	var pushDuration time.Duration
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), client, config, content, nil, resolver,
+			staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(b, err)
+		pushDuration += result.Duration
+	}
+	b.ReportMetric(float64(pushDuration)/float64(time.Millisecond)/float64(b.N), "push-ms/op")
+}
@@ -0,0 +1,1277 @@
+package sendconfig_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"github.com/kong/deck/file"
+	deckutils "github.com/kong/deck/utils"
+	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v3/test/helpers/certificate"
+)
+
+// staticUpdateStrategyResolver always resolves to the same UpdateStrategy.
+type staticUpdateStrategyResolver struct {
+	strategy sendconfig.UpdateStrategy
+}
+
+func (r staticUpdateStrategyResolver) ResolveUpdateStrategy(sendconfig.UpdateClient) sendconfig.UpdateStrategy {
+	return r.strategy
+}
+
+// staticConfigurationChangeDetector always returns the same result. A change is reported as a SHA change
+// unless change is set.
+type staticConfigurationChangeDetector struct {
+	hasChanged bool
+	change     sendconfig.ConfigurationChange
+}
+
+func (d staticConfigurationChangeDetector) HasConfigurationChanged(
+	context.Context, []byte, []byte, *file.Content, sendconfig.KonnectAwareClient, sendconfig.StatusClient,
+) (sendconfig.ConfigurationChange, error) {
+	if d.change != sendconfig.ConfigurationUnchanged {
+		return d.change, nil
+	}
+	if d.hasChanged {
+		return sendconfig.ConfigurationChangedSHA, nil
+	}
+	return sendconfig.ConfigurationUnchanged, nil
+}
+
+// diffReportingUpdateStrategy is an UpdateStrategy that reports a predefined diff.
+type diffReportingUpdateStrategy struct {
+	diff      mo.Option[sendconfig.DiffSummary]
+	wasCalled bool
+}
+
+func (s *diffReportingUpdateStrategy) Update(context.Context, sendconfig.ContentWithHash) (
+	stats sendconfig.UpdateStats,
+	err error,
+	resourceErrors []sendconfig.ResourceError,
+	resourceErrorsParseErr error,
+) {
+	s.wasCalled = true
+	return sendconfig.UpdateStats{Diff: s.diff}, nil, nil, nil
+}
+
+func (s *diffReportingUpdateStrategy) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolDeck
+}
+
+func (s *diffReportingUpdateStrategy) Type() string {
+	return "DiffReporting"
+}
+
+// canceledUpdateStrategy is an UpdateStrategy that waits for the context to be done, reporting a predefined diff
+// of changes applied before that.
+type canceledUpdateStrategy struct {
+	diff sendconfig.DiffSummary
+}
+
+func (s canceledUpdateStrategy) Update(ctx context.Context, _ sendconfig.ContentWithHash) (
+	stats sendconfig.UpdateStats,
+	err error,
+	resourceErrors []sendconfig.ResourceError,
+	resourceErrorsParseErr error,
+) {
+	<-ctx.Done()
+	return sendconfig.UpdateStats{Diff: mo.Some(s.diff)}, deckutils.ErrArray{Errors: []error{ctx.Err()}}, nil, nil
+}
+
+func (s canceledUpdateStrategy) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolDeck
+}
+
+func (s canceledUpdateStrategy) Type() string {
+	return "Canceled"
+}
+
+func testContent() *file.Content {
+	return &file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{Service: kong.Service{Name: kong.String("service")}},
+		},
+	}
+}
+
+func mustTestClient(t *testing.T) *adminapi.Client {
+	client, err := adminapi.NewTestClient("localhost:8001")
+	require.NoError(t, err)
+	return client
+}
+
+func TestPerformUpdate_ReturnsDiff(t *testing.T) {
+	ctx := context.Background()
+	promMetrics := metrics.NewCtrlFuncMetrics()
+
+	t.Run("diff reported by strategy is returned", func(t *testing.T) {
+		diff := sendconfig.DiffSummary{Creating: 1, Updating: 2, Deleting: 3}
+		strategy := &diffReportingUpdateStrategy{diff: mo.Some(diff)}
+
+		result, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), mustTestClient(t), sendconfig.Config{}, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+		require.True(t, strategy.wasCalled)
+		require.NotEmpty(t, result.ConfigSHA)
+		require.Equal(t, mo.Some(diff), result.Diff)
+		require.True(t, result.Pushed)
+	})
+
+	t.Run("skipped update reports no changes", func(t *testing.T) {
+		strategy := &diffReportingUpdateStrategy{}
+		client := mustTestClient(t)
+		client.SetLastConfigSHA([]byte("last-sha"))
+
+		result, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), client, sendconfig.Config{}, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: false},
+		)
+		require.NoError(t, err)
+		require.False(t, strategy.wasCalled)
+		require.Equal(t, []byte("last-sha"), result.ConfigSHA)
+		require.False(t, result.Pushed)
+		diff, ok := result.Diff.Get()
+		require.True(t, ok)
+		require.False(t, diff.HasChanges())
+	})
+}
+
+func TestPerformUpdate_SkipStatusCheckOnEqualSHA(t *testing.T) {
+	ctx := context.Background()
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	content := testContent()
+	sha, err := deckgen.GenerateSHA(content)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                      string
+		skipStatusCheckOnEqualSHA bool
+		expectUpdate              bool
+	}{
+		{
+			name:                      "change detector is consulted when disabled",
+			skipStatusCheckOnEqualSHA: false,
+			expectUpdate:              true,
+		},
+		{
+			name:                      "change detector is not consulted when enabled",
+			skipStatusCheckOnEqualSHA: true,
+			expectUpdate:              false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			strategy := &diffReportingUpdateStrategy{}
+			client := mustTestClient(t)
+			client.SetLastConfigSHA(sha)
+
+			// Change detector always reports a change, e.g. as if Kong reported the initial configuration hash.
+			_, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), client,
+				sendconfig.Config{SkipStatusCheckOnEqualSHA: tc.skipStatusCheckOnEqualSHA}, content,
+				promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectUpdate, strategy.wasCalled)
+		})
+	}
+}
+
+func TestPerformUpdate_Fingerprinter(t *testing.T) {
+	fingerprint := []byte("custom-fingerprint")
+	config := sendconfig.Config{
+		SkipStatusCheckOnEqualSHA: true,
+		Fingerprinter: func(*file.Content) ([]byte, error) {
+			return fingerprint, nil
+		},
+	}
+	client := mustTestClient(t)
+
+	strategy := &diffReportingUpdateStrategy{}
+	result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	require.True(t, strategy.wasCalled)
+	require.Equal(t, fingerprint, result.ConfigSHA)
+
+	t.Log("configuration with the same fingerprint is not pushed again")
+	client.SetLastConfigSHA(result.ConfigSHA)
+	strategy = &diffReportingUpdateStrategy{}
+	_, _, err = sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	require.False(t, strategy.wasCalled)
+
+	t.Log("fingerprinter's error fails the update")
+	config.Fingerprinter = func(*file.Content) ([]byte, error) {
+		return nil, errors.New("fingerprinting failed")
+	}
+	_, _, err = sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.Error(t, err)
+}
+
+func TestPerformUpdate_ContentTransformers(t *testing.T) {
+	ctx := context.Background()
+	promMetrics := metrics.NewCtrlFuncMetrics()
+
+	t.Run("transformers are run in order before the update", func(t *testing.T) {
+		var calls []string
+		tagTransformer := func(tag string) sendconfig.ContentTransformFunc {
+			return func(content *file.Content) error {
+				calls = append(calls, tag)
+				for i := range content.Services {
+					content.Services[i].Tags = append(content.Services[i].Tags, kong.String(tag))
+				}
+				return nil
+			}
+		}
+		content := testContent()
+		untransformedSHA, err := deckgen.GenerateSHA(content)
+		require.NoError(t, err)
+		strategy := &diffReportingUpdateStrategy{}
+
+		result, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), mustTestClient(t),
+			sendconfig.Config{ContentTransformers: []sendconfig.ContentTransformFunc{tagTransformer("first"), tagTransformer("second")}},
+			content, promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+		require.True(t, strategy.wasCalled)
+		require.Equal(t, []string{"first", "second"}, calls)
+		require.Equal(t, kong.StringSlice("first", "second"), content.Services[0].Tags)
+		require.NotEqual(t, untransformedSHA, result.ConfigSHA, "SHA should be calculated from transformed content")
+	})
+
+	t.Run("transformer error aborts the update", func(t *testing.T) {
+		strategy := &diffReportingUpdateStrategy{}
+		failingTransformer := func(*file.Content) error { return errors.New("transform failed") }
+
+		_, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), mustTestClient(t),
+			sendconfig.Config{ContentTransformers: []sendconfig.ContentTransformFunc{failingTransformer}},
+			testContent(), promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.ErrorIs(t, err, deckerrors.ContentTransformError{})
+		require.False(t, strategy.wasCalled)
+	})
+}
+
+func TestPerformUpdate_SyncScopeTagsNotSupportedInDBLessMode(t *testing.T) {
+	strategy := &diffReportingUpdateStrategy{}
+	client := mustTestClient(t)
+	client.SetLastConfigSHA([]byte("last-sha"))
+
+	result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client,
+		sendconfig.Config{InMemory: true, SyncScopeTags: []string{"k8s-namespace:default"}}, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.Error(t, err)
+	require.False(t, strategy.wasCalled)
+	require.Equal(t, []byte("last-sha"), result.ConfigSHA)
+}
+
+func TestPerformUpdate_NoConfigChangeLogThrottling(t *testing.T) {
+	const (
+		reconciles       = 100
+		noChangeLogEntry = "No configuration change, skipping sync to Kong"
+	)
+
+	testCases := []struct {
+		name             string
+		throttler        *sendconfig.SHALogThrottler
+		expectedLogCount int
+	}{
+		{
+			name:             "without throttler every skipped push is logged",
+			throttler:        nil,
+			expectedLogCount: reconciles,
+		},
+		{
+			name:             "with throttler skipped pushes of the same SHA are logged once per interval",
+			throttler:        sendconfig.NewSHALogThrottler(time.Hour),
+			expectedLogCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			logger := zapr.NewLogger(zap.New(core))
+			client := mustTestClient(t)
+			promMetrics := metrics.NewCtrlFuncMetrics()
+
+			for i := 0; i < reconciles; i++ {
+				_, _, err := sendconfig.PerformUpdate(context.Background(), logger, client,
+					sendconfig.Config{NoConfigChangeLogThrottler: tc.throttler}, testContent(),
+					promMetrics, staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}},
+					staticConfigurationChangeDetector{hasChanged: false},
+				)
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedLogCount, logs.FilterMessage(noChangeLogEntry).Len())
+		})
+	}
+}
+
+func TestSHALogThrottler(t *testing.T) {
+	throttler := sendconfig.NewSHALogThrottler(time.Hour)
+
+	require.True(t, throttler.ShouldLog("dataplane-1", []byte("sha-1")))
+	require.False(t, throttler.ShouldLog("dataplane-1", []byte("sha-1")), "same SHA within interval should be throttled")
+	require.True(t, throttler.ShouldLog("dataplane-2", []byte("sha-1")), "other data-plane should not be throttled")
+	require.True(t, throttler.ShouldLog("dataplane-1", []byte("sha-2")), "changed SHA should not be throttled")
+
+	var nilThrottler *sendconfig.SHALogThrottler
+	require.True(t, nilThrottler.ShouldLog("dataplane-1", []byte("sha-1")))
+	require.True(t, nilThrottler.ShouldLog("dataplane-1", []byte("sha-1")))
+}
+
+func TestPerformUpdate_RecordsPushPhaseDurations(t *testing.T) {
+	t.Run("both phases are recorded for an update", func(t *testing.T) {
+		promMetrics := metrics.NewCtrlFuncMetrics()
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t), sendconfig.Config{}, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, testutil.CollectAndCount(promMetrics.ConfigPushPhaseDuration))
+	})
+
+	t.Run("only preparation phase is recorded for a skipped update", func(t *testing.T) {
+		promMetrics := metrics.NewCtrlFuncMetrics()
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t), sendconfig.Config{}, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: false},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 1, testutil.CollectAndCount(promMetrics.ConfigPushPhaseDuration))
+	})
+}
+
+func TestPerformUpdate_OnApplied(t *testing.T) {
+	ctx := context.Background()
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	content := testContent()
+	sha, err := deckgen.GenerateSHA(content)
+	require.NoError(t, err)
+
+	type onAppliedCall struct {
+		newSHA  []byte
+		changed bool
+	}
+
+	testCases := []struct {
+		name          string
+		lastSHA       []byte
+		hasChanged    bool
+		expectedCalls []onAppliedCall
+	}{
+		{
+			name:          "called with changed for a new configuration",
+			lastSHA:       []byte("last-sha"),
+			hasChanged:    true,
+			expectedCalls: []onAppliedCall{{newSHA: sha, changed: true}},
+		},
+		{
+			name:          "called with not changed for a configuration re-synced with the same SHA",
+			lastSHA:       sha,
+			hasChanged:    true,
+			expectedCalls: []onAppliedCall{{newSHA: sha, changed: false}},
+		},
+		{
+			name:       "not called when the push is skipped",
+			lastSHA:    sha,
+			hasChanged: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			client := mustTestClient(t)
+			client.SetLastConfigSHA(tc.lastSHA)
+			var calls []onAppliedCall
+
+			_, _, err := sendconfig.PerformUpdate(ctx, logr.Discard(), client,
+				sendconfig.Config{OnApplied: func(newSHA []byte, changed bool) {
+					calls = append(calls, onAppliedCall{newSHA: newSHA, changed: changed})
+				}},
+				content, promMetrics, staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}},
+				staticConfigurationChangeDetector{hasChanged: tc.hasChanged},
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func TestPerformUpdate_ReverseSyncEntityTypesNotSupportedInDBLessMode(t *testing.T) {
+	strategy := &diffReportingUpdateStrategy{}
+	client := mustTestClient(t)
+	client.SetLastConfigSHA([]byte("last-sha"))
+
+	result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client,
+		sendconfig.Config{InMemory: true, ReverseSyncEntityTypes: []sendconfig.EntityType{sendconfig.EntityTypeCertificates}}, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.Error(t, err)
+	require.False(t, strategy.wasCalled)
+	require.Equal(t, []byte("last-sha"), result.ConfigSHA)
+}
+
+func TestPerformUpdate_Canceled(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zapr.NewLogger(zap.New(core))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := sendconfig.PerformUpdate(ctx, logger, mustTestClient(t), sendconfig.Config{}, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: canceledUpdateStrategy{diff: sendconfig.DiffSummary{Creating: 2}}},
+		staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.ErrorAs(t, err, &sendconfig.UpdateCanceledError{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	canceledLogs := logs.FilterMessageSnippet("Configuration update canceled").All()
+	require.Len(t, canceledLogs, 1)
+	require.Equal(t, int64(2), canceledLogs[0].ContextMap()["created"])
+}
+
+func TestPerformUpdate_SlowPushThreshold(t *testing.T) {
+	testCases := []struct {
+		name          string
+		threshold     time.Duration
+		expectWarning bool
+	}{
+		{name: "disabled", threshold: 0},
+		{name: "not exceeded", threshold: time.Hour},
+		{name: "exceeded", threshold: time.Nanosecond, expectWarning: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			logger := zapr.NewLogger(zap.New(core))
+
+			_, _, err := sendconfig.PerformUpdate(context.Background(), logger, mustTestClient(t),
+				sendconfig.Config{SlowPushThreshold: tc.threshold}, testContent(), metrics.NewCtrlFuncMetrics(),
+				staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+			)
+			require.NoError(t, err)
+
+			slowPushLogs := logs.FilterMessageSnippet("exceeded the slow push threshold").All()
+			if !tc.expectWarning {
+				require.Empty(t, slowPushLogs)
+				return
+			}
+			require.Len(t, slowPushLogs, 1)
+			require.Equal(t, string(metrics.ProtocolDeck), fmt.Sprint(slowPushLogs[0].ContextMap()["protocol"]))
+			require.Contains(t, slowPushLogs[0].ContextMap(), "entities")
+		})
+	}
+}
+
+func TestPerformUpdate_GeneratedConfigLog(t *testing.T) {
+	contentWithCredential := func() *file.Content {
+		content := testContent()
+		content.Consumers = []file.FConsumer{{
+			Consumer: kong.Consumer{Username: kong.String("consumer")},
+			KeyAuths: []*kong.KeyAuth{{Key: kong.String("secret-api-key")}},
+		}}
+		return content
+	}
+
+	testCases := []struct {
+		name        string
+		logLevel    int
+		loggerLevel int
+		expectLog   bool
+	}{
+		{name: "disabled", logLevel: 0, loggerLevel: util.TraceLevel},
+		{name: "enabled", logLevel: util.TraceLevel, loggerLevel: util.TraceLevel, expectLog: true},
+		{name: "logger not verbose enough", logLevel: util.TraceLevel, loggerLevel: util.DebugLevel},
+		{name: "normal verbosity is raised to trace level", logLevel: util.InfoLevel + 1, loggerLevel: util.DebugLevel},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.Level(-tc.loggerLevel))
+			logger := zapr.NewLogger(zap.New(core))
+
+			_, _, err := sendconfig.PerformUpdate(context.Background(), logger, mustTestClient(t),
+				sendconfig.Config{GeneratedConfigLogLevel: tc.logLevel}, contentWithCredential(), metrics.NewCtrlFuncMetrics(),
+				staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+			)
+			require.NoError(t, err)
+
+			configLogs := logs.FilterMessage("Generated configuration").All()
+			if !tc.expectLog {
+				require.Empty(t, configLogs)
+				return
+			}
+			require.Len(t, configLogs, 1)
+			logged := fmt.Sprint(configLogs[0].ContextMap()["config"])
+			require.Contains(t, logged, `"name":"service"`)
+			require.Contains(t, logged, `"key":"REDACTED"`)
+			require.NotContains(t, logged, "secret-api-key")
+			require.Contains(t, configLogs[0].ContextMap(), "config_sha")
+		})
+	}
+}
+
+func TestPerformUpdate_SyncID(t *testing.T) {
+	performUpdate := func(ctx context.Context, logger logr.Logger) sendconfig.UpdateResult {
+		result, _, err := sendconfig.PerformUpdate(ctx, logger, mustTestClient(t), sendconfig.Config{}, testContent(),
+			metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}},
+			staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("sync ID is generated and logged on every line", func(t *testing.T) {
+		core, logs := observer.New(zap.DebugLevel)
+		logger := zapr.NewLogger(zap.New(core))
+
+		result := performUpdate(context.Background(), logger)
+		require.NotEmpty(t, result.SyncID)
+		require.NotEmpty(t, logs.All())
+		for _, entry := range logs.All() {
+			require.Equal(t, result.SyncID, entry.ContextMap()["sync_id"], "line %q should have the sync ID", entry.Message)
+		}
+
+		require.NotEqual(t, result.SyncID, performUpdate(context.Background(), logr.Discard()).SyncID,
+			"every sync should have a unique ID")
+	})
+
+	t.Run("sync ID is taken from the context", func(t *testing.T) {
+		ctx := sendconfig.ContextWithSyncID(context.Background(), "sync-id")
+		require.Equal(t, "sync-id", performUpdate(ctx, logr.Discard()).SyncID)
+	})
+}
+
+func TestPerformUpdate_StructuredLogFields(t *testing.T) {
+	content := testContent()
+	sha, err := deckgen.GenerateSHA(content)
+	require.NoError(t, err)
+	expectedSHA := hex.EncodeToString(sha)
+
+	t.Run("successful sync", func(t *testing.T) {
+		core, logs := observer.New(zap.DebugLevel)
+		logger := zapr.NewLogger(zap.New(core))
+
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logger, mustTestClient(t), sendconfig.Config{}, testContent(),
+			metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}},
+			staticConfigurationChangeDetector{hasChanged: true},
+		)
+		require.NoError(t, err)
+
+		syncedLogs := logs.FilterMessage("Successfully synced configuration to Kong").All()
+		require.Len(t, syncedLogs, 1)
+		fields := syncedLogs[0].ContextMap()
+		require.Equal(t, expectedSHA, fields["config_sha"])
+		require.Equal(t, string(metrics.ProtocolDeck), fields["protocol"])
+		require.Equal(t, int64(deckgen.CountEntities(content)), fields["entities"])
+		require.Contains(t, fields, "duration")
+	})
+
+	t.Run("skipped sync", func(t *testing.T) {
+		core, logs := observer.New(zap.DebugLevel)
+		logger := zapr.NewLogger(zap.New(core))
+
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logger, mustTestClient(t), sendconfig.Config{}, testContent(),
+			metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}},
+			staticConfigurationChangeDetector{hasChanged: false},
+		)
+		require.NoError(t, err)
+
+		skippedLogs := logs.FilterMessage("No configuration change, skipping sync to Kong").All()
+		require.Len(t, skippedLogs, 1)
+		require.Equal(t, expectedSHA, skippedLogs[0].ContextMap()["config_sha"])
+	})
+}
+
+func TestPerformUpdate_AuthErrorDBLess(t *testing.T) {
+	for _, statusCode := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			logger := zapr.NewLogger(zap.New(core))
+			config := sendconfig.Config{InMemory: true}
+
+			result, _, err := sendconfig.PerformUpdate(context.Background(), logger,
+				adminapi.NewClient(newConfigRejectingKong(t, statusCode)), config, testContent(), metrics.NewCtrlFuncMetrics(),
+				sendconfig.NewDefaultUpdateStrategyResolver(config, logger), staticConfigurationChangeDetector{hasChanged: true},
+			)
+			require.True(t, deckerrors.IsAuthErr(err))
+			require.Equal(t, metrics.FailureReasonAuth, result.FailureReason)
+			require.Len(t, logs.FilterMessageSnippet("rejected the controller's credentials").All(), 1,
+				"credentials hint should be logged")
+		})
+	}
+}
+
+func TestPerformUpdate_RetriesDBLessServerErrors(t *testing.T) {
+	var pushes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if pushes.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "An unexpected error occurred"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+	client, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+	config := sendconfig.Config{
+		InMemory:        true,
+		PushRetryPolicy: sendconfig.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+	}
+
+	_, _, err = sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+		metrics.NewCtrlFuncMetrics(), sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()),
+		staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), pushes.Load(), "push failing with 500 should be retried")
+}
+
+func TestPerformUpdate_DryRunDoesNotWrite(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		writes []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			lock.Lock()
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			lock.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version": "3.4.0", "configuration": {"database": "off"}}`))
+			return
+		}
+		// Kong's current configuration is empty.
+		_, _ = w.Write([]byte(`{"data": [], "next": null}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+	config := sendconfig.Config{
+		InMemory:    true,
+		DryRun:      true,
+		Version:     semver.MustParse("3.4.0"),
+		Concurrency: 1,
+	}
+
+	result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+		metrics.NewCtrlFuncMetrics(), sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()),
+		staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, mo.Some(sendconfig.DiffSummary{Creating: 1}), result.Diff)
+	require.False(t, result.Pushed)
+	require.Empty(t, writes, "no changes should be sent to the Admin API in the dry run mode")
+}
+
+// requestingUpdateStrategy is an UpdateStrategy that sends a request to the URL using the HTTP client.
+type requestingUpdateStrategy struct {
+	httpClient *http.Client
+	url        string
+}
+
+func (s requestingUpdateStrategy) Update(ctx context.Context, _ sendconfig.ContentWithHash) (
+	stats sendconfig.UpdateStats,
+	err error,
+	resourceErrors []sendconfig.ResourceError,
+	resourceErrorsParseErr error,
+) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, nil)
+	if err != nil {
+		return sendconfig.UpdateStats{}, err, nil, nil
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return sendconfig.UpdateStats{}, err, nil, nil
+	}
+	return sendconfig.UpdateStats{}, resp.Body.Close(), nil, nil
+}
+
+func (s requestingUpdateStrategy) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolDBLess
+}
+
+func (s requestingUpdateStrategy) Type() string {
+	return "Requesting"
+}
+
+func TestPerformUpdate_CorrelationID(t *testing.T) {
+	var receivedCorrelationID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedCorrelationID = r.Header.Get(adminapi.HeaderNameCorrelationID)
+		w.Header().Set(adminapi.HeaderNameKongAdminRequestID, "kong-request-id")
+	}))
+	t.Cleanup(server.Close)
+	httpClient, err := adminapi.MakeHTTPClient(&adminapi.HTTPClientOpts{}, "")
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zapr.NewLogger(zap.New(core))
+	ctx := adminapi.ContextWithCorrelationID(context.Background(), "correlation-id")
+
+	_, _, err = sendconfig.PerformUpdate(ctx, logger, mustTestClient(t), sendconfig.Config{}, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: requestingUpdateStrategy{httpClient: httpClient, url: server.URL + "/config"}},
+		staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "correlation-id", receivedCorrelationID)
+
+	syncedLogs := logs.FilterMessage("Successfully synced configuration to Kong").All()
+	require.Len(t, syncedLogs, 1)
+	fields := syncedLogs[0].ContextMap()
+	require.Equal(t, "correlation-id", fields["correlation_id"])
+	require.Equal(t, "kong-request-id", fields["kong_request_id"])
+}
+
+func TestPerformUpdate_NilMetrics(t *testing.T) {
+	testCases := []struct {
+		name          string
+		strategy      sendconfig.UpdateStrategy
+		hasChanged    bool
+		expectedError bool
+	}{
+		{
+			name:       "successful push",
+			strategy:   &diffReportingUpdateStrategy{},
+			hasChanged: true,
+		},
+		{
+			name:       "skipped push",
+			strategy:   &diffReportingUpdateStrategy{},
+			hasChanged: false,
+		},
+		{
+			name:          "failed push",
+			strategy:      requestingUpdateStrategy{httpClient: http.DefaultClient, url: "http://127.0.0.1:0/config"},
+			hasChanged:    true,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.NotPanics(t, func() {
+				_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t), sendconfig.Config{}, testContent(),
+					nil, staticUpdateStrategyResolver{strategy: tc.strategy}, staticConfigurationChangeDetector{hasChanged: tc.hasChanged},
+				)
+				if tc.expectedError {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+			})
+		})
+	}
+}
+
+func TestPerformUpdate_ReturnsPushError(t *testing.T) {
+	strategy := requestingUpdateStrategy{httpClient: http.DefaultClient, url: "http://127.0.0.1:0/config"}
+	_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t), sendconfig.Config{}, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+
+	var pushErr sendconfig.PushError
+	require.ErrorAs(t, err, &pushErr)
+	require.Equal(t, metrics.FailureReasonNetwork, pushErr.FailureReason)
+	require.Zero(t, pushErr.StatusCode)
+	require.True(t, pushErr.Retriable)
+}
+
+func TestPerformUpdate_ReturnsPushErrorDBLess(t *testing.T) {
+	testCases := []struct {
+		statusCode            int
+		expectedFailureReason string
+		expectedRetriable     bool
+	}{
+		{
+			statusCode:            http.StatusBadRequest,
+			expectedFailureReason: metrics.FailureReasonValidation,
+		},
+		{
+			statusCode:            http.StatusInternalServerError,
+			expectedFailureReason: metrics.FailureReasonOther,
+			expectedRetriable:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(http.StatusText(tc.statusCode), func(t *testing.T) {
+			config := sendconfig.Config{InMemory: true}
+			_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(),
+				adminapi.NewClient(newConfigRejectingKong(t, tc.statusCode)), config, testContent(), metrics.NewCtrlFuncMetrics(),
+				sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()), staticConfigurationChangeDetector{hasChanged: true},
+			)
+
+			var pushErr sendconfig.PushError
+			require.ErrorAs(t, err, &pushErr)
+			require.Equal(t, tc.statusCode, pushErr.StatusCode)
+			require.Equal(t, tc.expectedFailureReason, pushErr.FailureReason)
+			require.Equal(t, tc.expectedRetriable, pushErr.Retriable)
+		})
+	}
+}
+
+func TestPerformUpdate_RecordsSyncSkipped(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	client := mustTestClient(t)
+	performUpdate := func(hasChanged bool) {
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, sendconfig.Config{}, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: hasChanged},
+		)
+		require.NoError(t, err)
+	}
+
+	performUpdate(true)
+	performUpdate(false)
+	performUpdate(false)
+
+	require.Equal(t, float64(2), testutil.ToFloat64(promMetrics.ConfigSyncSkippedCount.WithLabelValues(client.BaseRootURL())))
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigPushCount.With(map[string]string{
+		metrics.SuccessKey:       metrics.SuccessTrue,
+		metrics.ProtocolKey:      string(metrics.ProtocolDeck),
+		metrics.FailureReasonKey: "",
+		metrics.DataplaneKey:     client.BaseRootURL(),
+	})))
+}
+
+func TestPerformUpdate_ForcedResync(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	client := mustTestClient(t)
+	// Resync is due right after it's scheduled.
+	config := sendconfig.Config{ForcedResync: sendconfig.NewForcedResync(time.Nanosecond, 0)}
+	performUpdate := func() sendconfig.UpdateResult {
+		result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: false},
+		)
+		require.NoError(t, err)
+		return result
+	}
+
+	require.False(t, performUpdate().Pushed, "the first resync should only get scheduled")
+	require.Zero(t, testutil.CollectAndCount(promMetrics.ConfigForcedResyncCount))
+
+	time.Sleep(time.Millisecond)
+	require.True(t, performUpdate().Pushed, "configuration should be pushed despite not having changed")
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigForcedResyncCount.WithLabelValues(client.BaseRootURL())))
+}
+
+func TestPerformUpdate_InitialHashGracePeriod(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	content := testContent()
+	sha, err := deckgen.GenerateSHA(content)
+	require.NoError(t, err)
+	client := mustTestClient(t)
+	client.SetLastConfigSHA(sha)
+	config := sendconfig.Config{InitialHashGracePeriod: sendconfig.NewInitialHashGracePeriod(time.Millisecond)}
+	performUpdate := func(kongHasNoConfiguration bool) *diffReportingUpdateStrategy {
+		strategy := &diffReportingUpdateStrategy{}
+		detector := staticConfigurationChangeDetector{}
+		if kongHasNoConfiguration {
+			detector.change = sendconfig.ConfigurationChangedInitialHash
+		}
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, content,
+			promMetrics, staticUpdateStrategyResolver{strategy: strategy}, detector,
+		)
+		require.NoError(t, err)
+		return strategy
+	}
+
+	require.False(t, performUpdate(true).wasCalled, "re-sync should be deferred by the grace period")
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigHashInitialGraceCount.WithLabelValues(client.BaseRootURL())))
+
+	time.Sleep(2 * time.Millisecond)
+	require.True(t, performUpdate(true).wasCalled, "configuration should be re-synced after the grace period")
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigHashInitialCount.WithLabelValues(client.BaseRootURL())))
+
+	require.False(t, performUpdate(false).wasCalled)
+	require.False(t, performUpdate(true).wasCalled, "a new grace period should start once Kong has had configuration")
+	require.Equal(t, float64(2), testutil.ToFloat64(promMetrics.ConfigHashInitialGraceCount.WithLabelValues(client.BaseRootURL())))
+}
+
+// failingStatusConfigurationChangeDetector runs the wrapped detector against a status client that always fails.
+type failingStatusConfigurationChangeDetector struct {
+	detector sendconfig.ConfigurationChangeDetector
+}
+
+func (d failingStatusConfigurationChangeDetector) HasConfigurationChanged(
+	ctx context.Context, oldSHA, newSHA []byte, targetConfig *file.Content, client sendconfig.KonnectAwareClient, _ sendconfig.StatusClient,
+) (sendconfig.ConfigurationChange, error) {
+	return d.detector.HasConfigurationChanged(ctx, oldSHA, newSHA, targetConfig, client,
+		statusClientMock{expectedError: errors.New("status unavailable")},
+	)
+}
+
+func TestPerformUpdate_StatusErrorForcePushIgnoresInitialHashGracePeriod(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	content := testContent()
+	sha, err := deckgen.GenerateSHA(content)
+	require.NoError(t, err)
+	client := mustTestClient(t)
+	client.SetLastConfigSHA(sha)
+	config := sendconfig.Config{InitialHashGracePeriod: sendconfig.NewInitialHashGracePeriod(time.Hour)}
+	detector := failingStatusConfigurationChangeDetector{
+		detector: sendconfig.NewDefaultConfigurationChangeDetector(logr.Discard()).
+			WithStatusErrorFallback(sendconfig.StatusErrorFallbackForcePush),
+	}
+
+	strategy := &diffReportingUpdateStrategy{}
+	_, _, err = sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, content,
+		promMetrics, staticUpdateStrategyResolver{strategy: strategy}, detector,
+	)
+	require.NoError(t, err)
+	require.True(t, strategy.wasCalled, "configuration should be pushed right away when the status is unknown")
+	require.Zero(t, testutil.CollectAndCount(promMetrics.ConfigHashInitialGraceCount), "initial hash wasn't observed")
+	require.Zero(t, testutil.CollectAndCount(promMetrics.ConfigHashInitialCount), "initial hash wasn't observed")
+}
+
+func TestPerformUpdate_ValidateCertificates(t *testing.T) {
+	cert, _ := certificate.MustGenerateSelfSignedCertPEMFormat()
+	_, otherKey := certificate.MustGenerateSelfSignedCertPEMFormat()
+	content := testContent()
+	content.Certificates = []file.FCertificate{
+		{ID: kong.String("cert-1"), Cert: kong.String(string(cert)), Key: kong.String(string(otherKey))},
+	}
+	strategy := &diffReportingUpdateStrategy{}
+
+	result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t),
+		sendconfig.Config{ValidateCertificates: true}, content, metrics.NewCtrlFuncMetrics(),
+		staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.ErrorAs(t, err, &deckerrors.CertificateValidationError{})
+	require.Equal(t, metrics.FailureReasonValidation, result.FailureReason)
+	require.False(t, strategy.wasCalled, "configuration with an invalid certificate shouldn't be pushed")
+}
+
+func TestPerformUpdate_EmptyConfigGuard(t *testing.T) {
+	client := mustTestClient(t)
+	config := sendconfig.Config{EmptyConfigGuard: sendconfig.NewEmptyConfigGuard(0)}
+	performUpdate := func(content *file.Content, strategy sendconfig.UpdateStrategy) error {
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, content,
+			metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy},
+			staticConfigurationChangeDetector{hasChanged: true},
+		)
+		return err
+	}
+
+	require.NoError(t, performUpdate(testContent(), &diffReportingUpdateStrategy{}))
+
+	strategy := &diffReportingUpdateStrategy{}
+	err := performUpdate(&file.Content{}, strategy)
+	require.ErrorAs(t, err, &sendconfig.EmptyConfigGuardError{})
+	require.False(t, strategy.wasCalled, "empty configuration shouldn't be pushed after a non-empty one")
+}
+
+func TestPerformUpdate_SyncPause(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	client := mustTestClient(t)
+	strategy := &diffReportingUpdateStrategy{}
+	config := sendconfig.Config{SyncPause: sendconfig.NewSyncPause()}
+	performUpdate := func() (sendconfig.UpdateResult, error) {
+		result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: false},
+		)
+		return result, err
+	}
+
+	config.SyncPause.Pause()
+	_, err := performUpdate()
+	require.ErrorAs(t, err, &sendconfig.UpdateSkippedDueToSyncPauseError{})
+	require.False(t, strategy.wasCalled)
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigSyncPausedCount.WithLabelValues(client.BaseRootURL())))
+	latestSHA, ok := config.SyncPause.LatestSHA(client.BaseRootURL())
+	require.True(t, ok)
+	expectedSHA, err := deckgen.GenerateSHA(testContent())
+	require.NoError(t, err)
+	require.Equal(t, expectedSHA, latestSHA)
+
+	config.SyncPause.Resume()
+	result, err := performUpdate()
+	require.NoError(t, err)
+	require.True(t, result.Pushed, "configuration should be pushed after resuming despite not having changed")
+	_, ok = config.SyncPause.LatestSHA(client.BaseRootURL())
+	require.False(t, ok)
+
+	result, err = performUpdate()
+	require.NoError(t, err)
+	require.False(t, result.Pushed)
+}
+
+// announcingPushLockClient is an AdminAPIClient whose push lock announces every attempt to acquire it on waiting.
+type announcingPushLockClient struct {
+	*adminapi.Client
+	lock    sync.Mutex
+	waiting chan struct{}
+}
+
+func (c *announcingPushLockClient) PushLock() sync.Locker {
+	return announcingLocker{lock: &c.lock, waiting: c.waiting}
+}
+
+type announcingLocker struct {
+	lock    *sync.Mutex
+	waiting chan struct{}
+}
+
+func (l announcingLocker) Lock() {
+	l.waiting <- struct{}{}
+	l.lock.Lock()
+}
+
+func (l announcingLocker) Unlock() {
+	l.lock.Unlock()
+}
+
+func TestPerformUpdate_PushCoalescer(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	client := &announcingPushLockClient{Client: mustTestClient(t), waiting: make(chan struct{})}
+	strategy := &diffReportingUpdateStrategy{}
+	config := sendconfig.Config{PushCoalescer: sendconfig.NewPushCoalescer()}
+	performUpdate := func(errs chan<- error) {
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+		)
+		errs <- err
+	}
+
+	// Simulate a push in flight by holding the lock while requesting two more pushes.
+	client.lock.Lock()
+	intermediateErr := make(chan error, 1)
+	go performUpdate(intermediateErr)
+	<-client.waiting
+	latestErr := make(chan error, 1)
+	go performUpdate(latestErr)
+	<-client.waiting
+	client.lock.Unlock()
+
+	require.ErrorAs(t, <-intermediateErr, &sendconfig.UpdateSkippedDueToCoalescingError{})
+	require.NoError(t, <-latestErr)
+	require.True(t, strategy.wasCalled)
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigPushCoalescedCount.WithLabelValues(client.BaseRootURL())))
+
+	t.Run("sequential pushes are not coalesced", func(t *testing.T) {
+		errs := make(chan error, 1)
+		go performUpdate(errs)
+		<-client.waiting
+		require.NoError(t, <-errs)
+	})
+}
+
+// erroringUpdateStrategy is an UpdateStrategy always failing with err.
+type erroringUpdateStrategy struct {
+	err   error
+	calls int
+}
+
+func (s *erroringUpdateStrategy) Update(context.Context, sendconfig.ContentWithHash) (
+	stats sendconfig.UpdateStats,
+	err error,
+	resourceErrors []sendconfig.ResourceError,
+	resourceErrorsParseErr error,
+) {
+	s.calls++
+	return sendconfig.UpdateStats{}, s.err, nil, nil
+}
+
+func (s *erroringUpdateStrategy) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolDeck
+}
+
+func (s *erroringUpdateStrategy) Type() string {
+	return "Erroring"
+}
+
+func TestPerformUpdate_ResultDescribesPush(t *testing.T) {
+	client := mustTestClient(t)
+	performUpdate := func(strategy sendconfig.UpdateStrategy, hasChanged bool) (sendconfig.UpdateResult, error) {
+		result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, sendconfig.Config{}, testContent(),
+			nil, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: hasChanged},
+		)
+		return result, err
+	}
+
+	t.Run("successful push", func(t *testing.T) {
+		result, err := performUpdate(&diffReportingUpdateStrategy{}, true)
+		require.NoError(t, err)
+		require.True(t, result.Pushed)
+		require.Positive(t, result.Duration)
+		require.Equal(t, metrics.ProtocolDeck, result.Protocol)
+		require.Empty(t, result.FailureReason)
+	})
+
+	t.Run("failed push", func(t *testing.T) {
+		result, err := performUpdate(&erroringUpdateStrategy{err: kong.NewAPIError(http.StatusBadRequest, "invalid")}, true)
+		require.Error(t, err)
+		require.False(t, result.Pushed)
+		require.Positive(t, result.Duration)
+		require.Equal(t, metrics.ProtocolDeck, result.Protocol)
+		require.Equal(t, metrics.FailureReasonValidation, result.FailureReason)
+	})
+
+	t.Run("skipped push", func(t *testing.T) {
+		result, err := performUpdate(&diffReportingUpdateStrategy{}, false)
+		require.NoError(t, err)
+		require.False(t, result.Pushed)
+		require.Zero(t, result.Duration)
+		require.Empty(t, result.Protocol)
+	})
+}
+
+func TestPerformUpdate_ConflictCircuitBreaker(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	client := mustTestClient(t)
+	strategy := &erroringUpdateStrategy{err: kong.NewAPIError(http.StatusConflict, "conflict")}
+	config := sendconfig.Config{ConflictCircuitBreaker: sendconfig.NewConflictCircuitBreaker(2, time.Hour)}
+	performUpdate := func() error {
+		_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, config, testContent(),
+			promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+		)
+		return err
+	}
+
+	require.True(t, deckerrors.IsConflictErr(performUpdate()))
+	require.True(t, deckerrors.IsConflictErr(performUpdate()))
+	require.ErrorAs(t, performUpdate(), &sendconfig.UpdateSkippedDueToOpenCircuitError{})
+	require.Equal(t, 2, strategy.calls, "configuration should not be pushed while the circuit is open")
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigPushCircuitOpenCount.WithLabelValues(client.BaseRootURL())))
+}
+
+func TestPerformUpdate_RecordsEntityCounts(t *testing.T) {
+	promMetrics := metrics.NewCtrlFuncMetrics()
+	client := mustTestClient(t)
+
+	_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, sendconfig.Config{}, testContent(),
+		promMetrics, staticUpdateStrategyResolver{strategy: &diffReportingUpdateStrategy{}}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, float64(1), testutil.ToFloat64(promMetrics.ConfigEntityCount.WithLabelValues(client.BaseRootURL(), "services")))
+	require.Equal(t, float64(0), testutil.ToFloat64(promMetrics.ConfigEntityCount.WithLabelValues(client.BaseRootURL(), "routes")))
+}
+
+func TestPerformUpdate_ReadinessGate(t *testing.T) {
+	ready := false
+	config := sendconfig.Config{ReadinessGate: func() bool { return ready }}
+
+	strategy := &diffReportingUpdateStrategy{}
+	_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t), config, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.ErrorAs(t, err, &sendconfig.UpdateSkippedDueToReadinessGateError{})
+	require.False(t, strategy.wasCalled, "configuration should not be pushed before the readiness gate opens")
+
+	ready = true
+	result, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), mustTestClient(t), config, testContent(),
+		metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+	)
+	require.NoError(t, err)
+	require.True(t, strategy.wasCalled)
+	require.True(t, result.Pushed)
+}
+
+func TestNewSettleDelayReadinessGate(t *testing.T) {
+	require.True(t, sendconfig.NewSettleDelayReadinessGate(0)(), "gate with no delay should be open right away")
+
+	gate := sendconfig.NewSettleDelayReadinessGate(50 * time.Millisecond)
+	require.False(t, gate())
+	require.Eventually(t, gate, time.Second, 10*time.Millisecond)
+}
+
+// inFlightTrackingUpdateStrategy records the maximum number of its Update calls that were in progress at once.
+type inFlightTrackingUpdateStrategy struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	calls       atomic.Int32
+}
+
+func (s *inFlightTrackingUpdateStrategy) Update(context.Context, sendconfig.ContentWithHash) (
+	sendconfig.UpdateStats, error, []sendconfig.ResourceError, error,
+) {
+	s.calls.Add(1)
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		maxInFlight := s.maxInFlight.Load()
+		if n <= maxInFlight || s.maxInFlight.CompareAndSwap(maxInFlight, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return sendconfig.UpdateStats{}, nil, nil, nil
+}
+
+func (s *inFlightTrackingUpdateStrategy) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolDBLess
+}
+
+func (s *inFlightTrackingUpdateStrategy) Type() string {
+	return "InFlightTracking"
+}
+
+func TestPerformUpdate_ConcurrentCallsAreSerialized(t *testing.T) {
+	const concurrentCalls = 5
+	client := mustTestClient(t)
+	strategy := &inFlightTrackingUpdateStrategy{}
+	promMetrics := metrics.NewCtrlFuncMetrics()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentCalls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := sendconfig.PerformUpdate(context.Background(), logr.Discard(), client, sendconfig.Config{}, testContent(),
+				promMetrics, staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+			)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(concurrentCalls), strategy.calls.Load())
+	require.Equal(t, int32(1), strategy.maxInFlight.Load(), "pushes to the same client should never run concurrently")
+}
@@ -2,11 +2,13 @@ package sendconfig
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/dump"
 	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/mo"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
@@ -18,10 +20,44 @@ type ContentWithHash struct {
 	Hash    []byte
 }
 
+// UpdateStats gathers information about the work done by an UpdateStrategy while applying configuration.
+type UpdateStats struct {
+	// PayloadSize is the size (in bytes) of the serialized configuration that was sent to the data-plane.
+	// It's empty when the configuration wasn't serialized (e.g. update failed before that happened).
+	PayloadSize mo.Option[int]
+
+	// PreparationDuration is the time spent by the strategy on preparing the configuration (e.g. serializing it)
+	// before sending it to the data-plane. It's a part of the whole update duration.
+	PreparationDuration time.Duration
+
+	// Diff summarizes changes made to the data-plane's configuration. It's available only for strategies
+	// that are able to calculate it (e.g. UpdateStrategyDBMode).
+	Diff mo.Option[DiffSummary]
+
+	// EntityTypeDurations approximates time spent on syncing entities of every type (keyed by decK's entity kind,
+	// e.g. "service" or "route"). It's available only for strategies syncing entities one type at a time
+	// (i.e. UpdateStrategyDBMode).
+	EntityTypeDurations map[string]time.Duration
+
+	// QueueWaitDurations approximate how long every entity change waited for a free sync worker before being sent
+	// to the data-plane. Long waits suggest increasing the sync concurrency would speed syncs up. It's available
+	// only for strategies syncing entities with a pool of workers (i.e. UpdateStrategyDBMode).
+	QueueWaitDurations []time.Duration
+
+	// DumpWaitDuration is the time spent waiting for the DumpLimiter to allow dumping the data-plane's current state.
+	// It's available only for strategies dumping the current state with a DumpLimiter (i.e. UpdateStrategyDBMode).
+	DumpWaitDuration mo.Option[time.Duration]
+
+	// RemovedPluginConfigNulls is the number of null fields removed from plugins' configs as Kong rejects them.
+	// It's available only for strategies removing them (i.e. UpdateStrategyInMemory).
+	RemovedPluginConfigNulls int
+}
+
 // UpdateStrategy is the way we approach updating data-plane's configuration, depending on its type.
 type UpdateStrategy interface {
 	// Update applies targetConfig to the data-plane.
 	Update(ctx context.Context, targetContent ContentWithHash) (
+		stats UpdateStats,
 		err error,
 		resourceErrors []ResourceError,
 		resourceErrorsParseErr error,
@@ -75,47 +111,93 @@ func NewDefaultUpdateStrategyResolver(config Config, logger logr.Logger) Default
 func (r DefaultUpdateStrategyResolver) ResolveUpdateStrategy(
 	client UpdateClient,
 ) UpdateStrategy {
-	updateStrategy := r.resolveUpdateStrategy(client)
+	return withClientBackoffStrategy(r.resolveUpdateStrategy(client), client, r.logger)
+}
 
+// withClientBackoffStrategy decorates the UpdateStrategy with the client's backoff strategy
+// if the client implements UpdateClientWithBackoff interface.
+func withClientBackoffStrategy(updateStrategy UpdateStrategy, client UpdateClient, logger logr.Logger) UpdateStrategy {
 	if clientWithBackoff, ok := client.(UpdateClientWithBackoff); ok {
-		return NewUpdateStrategyWithBackoff(updateStrategy, clientWithBackoff.BackoffStrategy(), r.logger)
+		return NewUpdateStrategyWithBackoff(updateStrategy, clientWithBackoff.BackoffStrategy(), logger)
 	}
 
 	return updateStrategy
 }
 
 func (r DefaultUpdateStrategyResolver) resolveUpdateStrategy(client UpdateClient) UpdateStrategy {
-	adminAPIClient := client.AdminAPIClient()
-
 	// In case the client communicates with Konnect Admin API, we know it has to use DB-mode. There's no need to check
 	// config.InMemory that is meant for regular Kong Gateway clients.
+	if client.IsKonnect() || !r.config.InMemory {
+		return newUpdateStrategyDBModeForClient(client, r.config, r.logger)
+	}
+
+	s := NewUpdateStrategyInMemory(
+		client.AdminAPIClient(),
+		DefaultContentToDBLessConfigConverter{PluginsKeepingNulls: r.config.InMemoryPluginsKeepingNulls},
+		r.logger,
+	).
+		WithCheckHash(!r.config.DisableCheckHash).
+		WithFlattenErrors(!r.config.DisableFlattenErrors).
+		WithMaxConfigBytes(r.config.MaxConfigBytes).
+		WithSensitiveFields(r.config.SensitiveFields)
+	if r.config.VerifyInMemoryPushes {
+		s = s.WithVerification(client.AdminAPIClient())
+	}
+	if r.config.ConfigHashTracker != nil {
+		s = s.WithConditionalPush(r.config.ConfigHashTracker, client.AdminAPIClient().BaseRootURL(), client.AdminAPIClient())
+	}
+	if len(r.config.ExternalEntityTags) > 0 {
+		s = s.WithExternalEntityTags(client.AdminAPIClient(), r.config.ExternalEntityTags)
+	}
+	return s
+}
+
+// newUpdateStrategyDBModeForClient returns an UpdateStrategyDBMode configured for a given client.
+// It can be used for both Konnect and Kong Gateway clients.
+func newUpdateStrategyDBModeForClient(client UpdateClient, config Config, logger logr.Logger) UpdateStrategyDBMode {
+	adminAPIClient := client.AdminAPIClient()
+
 	if client.IsKonnect() {
-		return NewUpdateStrategyDBModeKonnect(
+		// Konnect doesn't report configuration hash, hence its state is never cached.
+		s := NewUpdateStrategyDBModeKonnect(
 			adminAPIClient,
 			dump.Config{
 				SkipCACerts:         true,
 				KonnectControlPlane: client.KonnectControlPlane(),
+				SelectorTags:        config.SyncScopeTags,
 			},
-			r.config.Version,
-			r.config.Concurrency,
+			config.Version,
+			config.Concurrency,
+			logger,
 		)
+		if config.AutoConcurrency != nil {
+			s = s.WithAutoConcurrency(*config.AutoConcurrency)
+		}
+		return s.WithSyncerOptions(config.DeckSyncerOptions).
+			WithRollback(config.RollbackOnFailure).
+			WithDumpLimiter(config.DumpLimiter).
+			WithIgnoredEntityTypes(config.IgnoredEntityTypes).
+			WithChangeEvents(config.ChangeEvents)
 	}
 
-	if !r.config.InMemory {
-		return NewUpdateStrategyDBMode(
-			adminAPIClient,
-			dump.Config{
-				SkipCACerts:  r.config.SkipCACertificates,
-				SelectorTags: r.config.FilterTags,
-			},
-			r.config.Version,
-			r.config.Concurrency,
-		)
-	}
-
-	return NewUpdateStrategyInMemory(
+	s := NewUpdateStrategyDBMode(
 		adminAPIClient,
-		DefaultContentToDBLessConfigConverter{},
-		r.logger,
+		dump.Config{
+			SkipCACerts:  config.SkipCACertificates,
+			SelectorTags: append(append([]string{}, config.FilterTags...), config.SyncScopeTags...),
+		},
+		config.Version,
+		config.Concurrency,
+		logger,
 	)
+	if config.AutoConcurrency != nil {
+		s = s.WithAutoConcurrency(*config.AutoConcurrency)
+	}
+	s = s.WithMaxConfigBytes(config.MaxConfigBytes).
+		WithSyncerOptions(config.DeckSyncerOptions).
+		WithRollback(config.RollbackOnFailure).
+		WithDumpLimiter(config.DumpLimiter).
+		WithIgnoredEntityTypes(config.IgnoredEntityTypes).
+		WithChangeEvents(config.ChangeEvents)
+	return s
 }
@@ -0,0 +1,19 @@
+package sendconfig
+
+import (
+	"context"
+)
+
+type syncIDContextKey struct{}
+
+// ContextWithSyncID returns a context carrying the ID PerformUpdate identifies the configuration sync with,
+// e.g. to have it match an ID the caller has already logged. Otherwise, PerformUpdate generates a new one.
+func ContextWithSyncID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, syncIDContextKey{}, id)
+}
+
+// SyncIDFromContext returns the sync ID carried by the context, if any.
+func SyncIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(syncIDContextKey{}).(string)
+	return id, ok && id != ""
+}
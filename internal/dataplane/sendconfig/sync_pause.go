@@ -0,0 +1,101 @@
+package sendconfig
+
+import (
+	"sync"
+)
+
+// UpdateSkippedDueToSyncPauseError is returned from PerformUpdate when the configuration push was skipped due to
+// Config.SyncPause being paused.
+type UpdateSkippedDueToSyncPauseError struct{}
+
+func (e UpdateSkippedDueToSyncPauseError) Error() string {
+	return "update skipped due to configuration sync being paused"
+}
+
+// SyncPause lets configuration syncing be paused at runtime (e.g. during a maintenance) without restarting
+// the controller. While it's paused, PerformUpdate fails fast with UpdateSkippedDueToSyncPauseError, keeping track
+// of the latest configuration SHA it was called with for every data-plane. Once it's resumed, the next push to every
+// data-plane that had a push skipped is performed in full, even if the configuration SHA hasn't changed.
+// A nil SyncPause is never paused.
+type SyncPause struct {
+	lock    sync.Mutex
+	paused  bool
+	pending map[string][]byte // Latest SHAs of skipped pushes keyed by data-plane URL.
+}
+
+// NewSyncPause returns a SyncPause that's not paused.
+func NewSyncPause() *SyncPause {
+	return &SyncPause{
+		pending: map[string][]byte{},
+	}
+}
+
+// Pause pauses configuration syncing.
+func (p *SyncPause) Pause() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.paused = true
+}
+
+// Resume resumes configuration syncing.
+func (p *SyncPause) Resume() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.paused = false
+}
+
+// Paused tells whether configuration syncing is paused.
+func (p *SyncPause) Paused() bool {
+	if p == nil {
+		return false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.paused
+}
+
+// LatestSHA returns the SHA of the latest configuration whose push to the data-plane was skipped due to the pause
+// (and hasn't been pushed since).
+func (p *SyncPause) LatestSHA(dataplane string) ([]byte, bool) {
+	if p == nil {
+		return nil, false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	sha, ok := p.pending[dataplane]
+	return sha, ok
+}
+
+// skip returns UpdateSkippedDueToSyncPauseError when syncing is paused, recording the skipped configuration SHA.
+func (p *SyncPause) skip(dataplane string, sha []byte) error {
+	if p == nil {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !p.paused {
+		return nil
+	}
+	p.pending[dataplane] = sha
+	return UpdateSkippedDueToSyncPauseError{}
+}
+
+// resumed tells whether pushes to the data-plane were skipped due to the pause that has been resumed since.
+func (p *SyncPause) resumed(dataplane string) bool {
+	_, ok := p.LatestSHA(dataplane)
+	return ok
+}
+
+// synced marks the configuration of the data-plane as synced after the pause.
+func (p *SyncPause) synced(dataplane string) {
+	if p == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.pending, dataplane)
+}
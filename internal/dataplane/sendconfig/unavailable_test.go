@@ -0,0 +1,103 @@
+package sendconfig_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/versions"
+)
+
+// restartingKong is an Admin API responding with 503 Service Unavailable to the first unavailableResponses requests,
+// as Kong does while restarting during a rolling upgrade, and serving requests with the handler afterwards.
+type restartingKong struct {
+	handler http.Handler
+
+	lock                 sync.Mutex
+	unavailableResponses int
+}
+
+func (k *restartingKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.lock.Lock()
+	unavailable := k.unavailableResponses > 0
+	if unavailable {
+		k.unavailableResponses--
+	}
+	k.lock.Unlock()
+
+	if unavailable {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message": "service unavailable"}`))
+		return
+	}
+	k.handler.ServeHTTP(w, r)
+}
+
+func TestPerformUpdate_AdminAPIUnavailable(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config sendconfig.Config
+	}{
+		{
+			name:   "DB-less mode",
+			config: sendconfig.Config{InMemory: true},
+		},
+		{
+			name:   "DB mode",
+			config: sendconfig.Config{Version: versions.KICv3VersionCutoff, Concurrency: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newClient := func(t *testing.T, unavailableResponses int) *adminapi.Client {
+				server := httptest.NewServer(&restartingKong{
+					handler:              newFakeAdminAPIHandler(t, 0),
+					unavailableResponses: unavailableResponses,
+				})
+				t.Cleanup(server.Close)
+				client, err := adminapi.NewTestClient(server.URL)
+				require.NoError(t, err)
+				return client
+			}
+			performUpdate := func(client *adminapi.Client, config sendconfig.Config) (sendconfig.UpdateResult, error) {
+				result, _, err := sendconfig.PerformUpdate(
+					context.Background(), logr.Discard(), client, config, testContent(), metrics.NewCtrlFuncMetrics(),
+					sendconfig.NewDefaultUpdateStrategyResolver(config, logr.Discard()), staticConfigurationChangeDetector{hasChanged: true},
+				)
+				return result, err
+			}
+
+			t.Run("failure is classified as unavailable", func(t *testing.T) {
+				result, err := performUpdate(newClient(t, 1), tc.config)
+				require.Error(t, err)
+				require.Equal(t, metrics.FailureReasonUnavailable, result.FailureReason)
+			})
+
+			t.Run("push is retried until Kong is back", func(t *testing.T) {
+				config := tc.config
+				config.PushRetryPolicy = sendconfig.RetryPolicy{
+					MaxAttempts:      4,
+					BaseDelay:        time.Millisecond,
+					UnavailableDelay: 50 * time.Millisecond,
+				}
+				start := time.Now()
+				_, err := performUpdate(newClient(t, 1), config)
+				require.NoError(t, err)
+				require.GreaterOrEqual(t, time.Since(start), config.PushRetryPolicy.UnavailableDelay,
+					"retries should wait for the unavailable delay",
+				)
+			})
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package sendconfig
+
+import (
+	"errors"
+
+	"github.com/samber/lo"
+)
+
+// IsUpdateSkippedError tells whether the error signals that the configuration push was deliberately skipped
+// (i.e. it's one of the UpdateSkippedDueTo* errors) rather than failed. Nothing has been sent to Kong in such case.
+// Errors joining multiple ones (e.g. of pushes to multiple gateways) are considered skips only when all of them are.
+func IsUpdateSkippedError(err error) bool {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		return len(errs) > 0 && lo.EveryBy(errs, IsUpdateSkippedError)
+	}
+	return errors.As(err, &UpdateSkippedDueToBackoffStrategyError{}) ||
+		errors.As(err, &UpdateSkippedDueToReadinessGateError{}) ||
+		errors.As(err, &UpdateSkippedDueToOpenCircuitError{}) ||
+		errors.As(err, &UpdateSkippedDueToSyncPauseError{}) ||
+		errors.As(err, &UpdateSkippedDueToCoalescingError{}) ||
+		errors.As(err, &UpdateSkippedDueToRateLimitError{})
+}
@@ -0,0 +1,57 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/go-kong/kong"
+)
+
+// KongVersionMismatchAction defines what PerformUpdate does when the version of a Kong instance diverges
+// by a major version from the one the configuration is generated for (Config.Version).
+type KongVersionMismatchAction string
+
+const (
+	// KongVersionMismatchActionIgnore disables the version check.
+	KongVersionMismatchActionIgnore KongVersionMismatchAction = ""
+	// KongVersionMismatchActionWarn makes PerformUpdate log a warning and push the configuration anyway.
+	KongVersionMismatchActionWarn KongVersionMismatchAction = "warn"
+	// KongVersionMismatchActionError makes PerformUpdate fail with KongVersionMismatchError.
+	KongVersionMismatchActionError KongVersionMismatchAction = "error"
+)
+
+// KongVersionMismatchError is returned when a Kong instance's major version differs from the one
+// the configuration was generated for.
+type KongVersionMismatchError struct {
+	ExpectedVersion semver.Version
+	ActualVersion   semver.Version
+}
+
+func (e KongVersionMismatchError) Error() string {
+	return fmt.Sprintf("Kong version %s differs by a major version from %s the configuration was generated for",
+		e.ActualVersion, e.ExpectedVersion,
+	)
+}
+
+// checkKongVersion verifies that the version reported by the Kong instance has the same major version as expected.
+func checkKongVersion(ctx context.Context, client *kong.Client, expected semver.Version) error {
+	root, err := client.Root(ctx)
+	if err != nil {
+		return fmt.Errorf("failed fetching Kong root: %w", err)
+	}
+	version, ok := root["version"].(string)
+	if !ok {
+		return fmt.Errorf("malformed Kong version found in Kong root: %v", root["version"])
+	}
+	v, err := kong.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("failed parsing Kong version %q: %w", version, err)
+	}
+
+	actual := semver.Version{Major: v.Major(), Minor: v.Minor(), Patch: v.Patch()}
+	if actual.Major != expected.Major {
+		return KongVersionMismatchError{ExpectedVersion: expected, ActualVersion: actual}
+	}
+	return nil
+}
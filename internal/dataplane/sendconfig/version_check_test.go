@@ -0,0 +1,82 @@
+package sendconfig_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+)
+
+func TestPerformUpdate_OnKongVersionMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "3.4.1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name            string
+		configVersion   semver.Version
+		action          sendconfig.KongVersionMismatchAction
+		expectMismatch  bool
+		expectPerformed bool
+	}{
+		{
+			name:            "check is disabled by default",
+			configVersion:   semver.MustParse("2.8.0"),
+			expectPerformed: true,
+		},
+		{
+			name:            "same major version",
+			configVersion:   semver.MustParse("3.0.0"),
+			action:          sendconfig.KongVersionMismatchActionError,
+			expectPerformed: true,
+		},
+		{
+			name:            "different major version with warn action",
+			configVersion:   semver.MustParse("2.8.0"),
+			action:          sendconfig.KongVersionMismatchActionWarn,
+			expectPerformed: true,
+		},
+		{
+			name:           "different major version with error action",
+			configVersion:  semver.MustParse("2.8.0"),
+			action:         sendconfig.KongVersionMismatchActionError,
+			expectMismatch: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := adminapi.NewTestClient(server.URL)
+			require.NoError(t, err)
+			strategy := &diffReportingUpdateStrategy{}
+
+			_, _, err = sendconfig.PerformUpdate(context.Background(), logr.Discard(), client,
+				sendconfig.Config{Version: tc.configVersion, OnKongVersionMismatch: tc.action}, testContent(),
+				metrics.NewCtrlFuncMetrics(), staticUpdateStrategyResolver{strategy: strategy}, staticConfigurationChangeDetector{hasChanged: true},
+			)
+			if tc.expectMismatch {
+				var mismatchErr sendconfig.KongVersionMismatchError
+				require.ErrorAs(t, err, &mismatchErr)
+				require.Equal(t, semver.MustParse("3.4.1"), mismatchErr.ActualVersion)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectPerformed, strategy.wasCalled)
+		})
+	}
+}
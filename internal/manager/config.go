@@ -50,9 +50,12 @@ type Config struct {
 	KongAdminInitializationRetryDelay time.Duration
 	KongAdminToken                    string
 	KongAdminTokenPath                string
+	KongAdminInstanceID               string
 	KongWorkspace                     string
 	AnonymousReports                  bool
 	EnableReverseSync                 bool
+	ValidatePluginSchemas             bool
+	ValidateCertificates              bool
 	SyncPeriod                        time.Duration
 	SkipCACertificates                bool
 	CacheSyncTimeout                  time.Duration
@@ -68,12 +71,16 @@ type Config struct {
 	MetricsAddr                 string
 	ProbeAddr                   string
 	KongAdminURLs               []string
+	KongAdminBasePath           string
 	KongAdminSvc                OptionalNamespacedName
 	GatewayDiscoveryDNSStrategy cfgtypes.DNSStrategy
 	KongAdminSvcPortNames       []string
 	ProxySyncSeconds            float32
 	InitCacheSyncDuration       time.Duration
 	ProxyTimeoutSeconds         float32
+	InitialHashGracePeriod      time.Duration
+	AllowEmptyConfig            bool
+	EmptyConfigMinFraction      float64
 
 	// Kubernetes configurations
 	KubeconfigPath           string
@@ -81,7 +88,9 @@ type Config struct {
 	LeaderElectionNamespace  string
 	LeaderElectionID         string
 	Concurrency              int
+	MaxConcurrentDumps       int
 	FilterTags               []string
+	ExternalEntityTags       []string
 	WatchNamespaces          []string
 	GatewayAPIControllerName string
 	Impersonate              string
@@ -159,7 +168,8 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringVar(&c.KongAdminAPIConfig.CACertPath, "kong-admin-ca-cert-file", "", `Path to PEM-encoded CA certificate file to verify Kong's Admin TLS certificate. Mutually exclusive with --kong-admin-ca-cert.`)
 	flagSet.StringVar(&c.KongAdminAPIConfig.CACert, "kong-admin-ca-cert", "", `PEM-encoded CA certificate to verify Kong's Admin TLS certificate. Mutually exclusive with --kong-admin-ca-cert-file.`)
 
-	flagSet.StringSliceVar(&c.KongAdminAPIConfig.Headers, "kong-admin-header", nil, `Header(s) (key:value) in comma-separated format (or specify this flag multiple times) to add to every Admin API call.`)
+	flagSet.StringVar(&c.KongAdminInstanceID, "kong-admin-instance-id", "", `Identifier of the controller instance included in the User-Agent header of Admin API calls (along with the controller's version), letting Admin API access logs tell which controller made a call. A User-Agent set with --kong-admin-header takes precedence.`)
+	flagSet.StringSliceVar(&c.KongAdminAPIConfig.Headers, "kong-admin-header", nil, `Header(s) (key:value) in comma-separated format (or specify this flag multiple times) to add to every Admin API call. Headers set by the controller itself (e.g. Content-Type) take precedence.`)
 	flagSet.UintVar(&c.KongAdminInitializationRetries, "kong-admin-init-retries", 60, "Number of attempts that will be made initially on controller startup to connect to the Kong Admin API.")
 	flagSet.DurationVar(&c.KongAdminInitializationRetryDelay, "kong-admin-init-retry-delay", time.Second, "The time delay between every attempt (on controller startup) to connect to the Kong Admin API.")
 	flagSet.StringVar(&c.KongAdminToken, "kong-admin-token", "", `The Kong Enterprise RBAC token used by the controller. Mutually exclusive with --kong-admin-token-file.`)
@@ -167,20 +177,24 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringVar(&c.KongWorkspace, "kong-workspace", "", "Kong Enterprise workspace to configure. Leave this empty if not using Kong workspaces.")
 	flagSet.BoolVar(&c.AnonymousReports, "anonymous-reports", true, `Send anonymized usage data to help improve Kong.`)
 	flagSet.BoolVar(&c.EnableReverseSync, "enable-reverse-sync", false, `Send configuration to Kong even if the configuration checksum has not changed since previous update.`)
+	flagSet.BoolVar(&c.ValidatePluginSchemas, "validate-plugin-schemas", false, `Validate plugins' configurations against their schemas fetched from Kong before sending configuration to Kong, reporting schema violations without sending the configuration.`)
+	flagSet.BoolVar(&c.ValidateCertificates, "validate-certificates", false, `Validate TLS certificates before sending configuration to Kong, reporting certificates that can't be parsed or don't match their private keys without sending the configuration, and warning about certificates expiring soon.`)
 	// Default has to be explicitly passed to generate the proper docs. See https://github.com/kubernetes-sigs/controller-runtime/blob/f1c5dd3851ce3df8b4b7830d9b6eae6271f6932d/pkg/cache/cache.go#L146-L151.
 	flagSet.DurationVar(&c.SyncPeriod, "sync-period", 10*time.Hour, `Determine the minimum frequency at which watched resources are reconciled. Set to 0 to use default from controller-runtime.`)
 	flagSet.BoolVar(&c.SkipCACertificates, "skip-ca-certificates", false, `Disable syncing CA certificate syncing (for use with multi-workspace environments).`)
 	// Default has to be explicitly passed to generate the proper docs. See https://github.com/kubernetes-sigs/controller-runtime/blob/f1c5dd3851ce3df8b4b7830d9b6eae6271f6932d/pkg/config/controller.go#L38-L39.
 	flagSet.DurationVar(&c.CacheSyncTimeout, "cache-sync-timeout", 2*time.Minute, `The time limit set to wait for syncing controllers' caches. Set to 0 to use default from controller-runtime.`)
 
-	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.CertFile, "kong-admin-tls-client-cert-file", "", "Mutual TLS (mTLS) client certificate file for authentication. Mutually exclusive with --kong-admin-tls-client-cert.")
+	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.CertFile, "kong-admin-tls-client-cert-file", "", "Mutual TLS (mTLS) client certificate file for authentication. Mutually exclusive with --kong-admin-tls-client-cert. When used with --kong-admin-tls-client-key-file, the certificate is reloaded on change.")
 	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.KeyFile, "kong-admin-tls-client-key-file", "", "Mutual TLS (mTLS) client key file for authentication. Mutually exclusive with --kong-admin-tls-client-key.")
 	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.Cert, "kong-admin-tls-client-cert", "", "Mutual TLS (mTLS) client certificate for authentication. Mutually exclusive with --kong-admin-tls-client-cert-file.")
 	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.Key, "kong-admin-tls-client-key", "", "Mutual TLS (mTLS) client key for authentication. Mutually exclusive with --kong-admin-tls-client-key-file.")
 
 	// Kong Admin API configuration.
 	flagSet.StringSliceVar(&c.KongAdminURLs, "kong-admin-url", []string{"http://localhost:8001"},
-		`Kong Admin URL(s) in comma-separated format (or specify this flag multiple times) to connect to in the format "protocol://address:port".`)
+		`Kong Admin URL(s) in comma-separated format (or specify this flag multiple times) to connect to in the format "protocol://address:port" or "unix:///path/to/admin.sock" for a Unix domain socket.`)
+	flagSet.StringVar(&c.KongAdminBasePath, "kong-admin-base-path", "",
+		`Path prefix under which Kong Admin API is served (e.g. behind a reverse proxy), appended to the Kong Admin URL(s) and to the discovered Admin API addresses. Can't be used with Unix domain socket addresses.`)
 	flagSet.Var(flags.NewValidatedValue(&c.KongAdminSvc, namespacedNameFromFlagValue, nnTypeNameOverride), "kong-admin-svc",
 		`Kong Admin API Service namespaced name in "namespace/name" format, to use for Kong Gateway service discovery.`)
 	flagSet.StringSliceVar(&c.KongAdminSvcPortNames, "kong-admin-svc-port-names", []string{"admin-tls", "kong-admin-tls"},
@@ -198,6 +212,15 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 		"Define the rate (in seconds) in which configuration updates will be applied to the Kong Admin API.")
 	flagSet.Float32Var(&c.ProxyTimeoutSeconds, "proxy-timeout-seconds", dataplane.DefaultTimeoutSeconds,
 		"Sets the timeout (in seconds) for all requests to Kong's Admin API.")
+	flagSet.DurationVar(&c.InitialHashGracePeriod, "initial-hash-grace-period", 0,
+		"Maximum random delay before re-syncing configuration to a Kong instance reporting no configuration (e.g. after a restart), "+
+			"so that multiple controller replicas don't push to it at once. Set to 0 to re-sync immediately.")
+	flagSet.BoolVar(&c.AllowEmptyConfig, "allow-empty-config", false,
+		"Allow pushing an empty configuration to a Kong instance that the controller has already pushed a non-empty one to. "+
+			"Such pushes are refused by default, as they remove all the entities from Kong.")
+	flagSet.Float64Var(&c.EmptyConfigMinFraction, "empty-config-min-fraction", 0,
+		"Refuse pushing a configuration with fewer entities than this fraction (between 0 and 1) of the configuration last pushed to a Kong instance, "+
+			"unless --allow-empty-config is set. Set to 0 to refuse empty configurations only.")
 
 	// Kubernetes configurations
 	flagSet.Var(flags.NewValidatedValue(&c.GatewayAPIControllerName, gatewayAPIControllerNameFromFlagValue, flags.WithDefault(string(gateway.GetControllerName()))), "gateway-api-controller-name", "The controller name to match on Gateway API resources.")
@@ -208,7 +231,14 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringSliceVar(&c.FilterTags, "kong-admin-filter-tag", []string{"managed-by-ingress-controller"},
 		"Tag(s) in comma-separated format (or specify this flag multiple times). They are used to manage and filter entities in Kong. "+
 			"This setting will be silently ignored if the Kong instance has no tags support.")
+	flagSet.StringSliceVar(&c.ExternalEntityTags, "kong-admin-external-entity-tag", nil,
+		"Tag(s) in comma-separated format (or specify this flag multiple times) of entities managed in Kong by other processes. "+
+			"In DB-less mode, configuration pushes that would remove such entities from Kong (as DB-less configuration is always replaced in full) are refused. "+
+			"This setting is ignored in DB mode.")
 	flagSet.IntVar(&c.Concurrency, "kong-admin-concurrency", 10, "Max number of concurrent requests sent to Kong's Admin API.")
+	flagSet.IntVar(&c.MaxConcurrentDumps, "kong-admin-max-concurrent-dumps", 0,
+		"Max number of Kong instances whose current configuration is fetched (dumped) at the same time in DB mode, "+
+			"so that Kong instances sharing a database don't overwhelm it. 0 means no limit.")
 	flagSet.StringSliceVar(&c.WatchNamespaces, "watch-namespace", nil,
 		`Namespace(s) in comma-separated format (or specify this flag multiple times) to watch for Kubernetes resources. Defaults to all namespaces.`)
 
@@ -301,6 +331,7 @@ func (c *Config) Resolve() error {
 		}
 		c.KongAdminToken = string(token)
 	}
+	c.KongAdminAPIConfig.UserAgent = adminapi.UserAgent(metadata.Release, c.KongAdminInstanceID)
 	return nil
 }
 
@@ -64,6 +64,10 @@ func (c *Config) Validate() error {
 		return errors.New("both admin token and admin token file specified, only one allowed")
 	}
 
+	if c.EmptyConfigMinFraction < 0 || c.EmptyConfigMinFraction > 1 {
+		return fmt.Errorf("--empty-config-min-fraction has to be between 0 and 1, got %v", c.EmptyConfigMinFraction)
+	}
+
 	if err := c.validateKonnect(); err != nil {
 		return fmt.Errorf("invalid konnect configuration: %w", err)
 	}
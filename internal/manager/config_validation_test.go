@@ -284,4 +284,16 @@ func TestConfigValidate(t *testing.T) {
 			require.ErrorContains(t, c.Validate(), "both admin token and admin token file specified, only one allowed")
 		})
 	})
+
+	t.Run("Empty config min fraction", func(t *testing.T) {
+		t.Run("fraction within range accepted", func(t *testing.T) {
+			c := manager.Config{EmptyConfigMinFraction: 0.5}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("fraction out of range rejected", func(t *testing.T) {
+			c := manager.Config{EmptyConfigMinFraction: 1.5}
+			require.ErrorContains(t, c.Validate(), "--empty-config-min-fraction has to be between 0 and 1")
+		})
+	})
 }
@@ -84,7 +84,8 @@ func Run(
 		return fmt.Errorf("failed to resolve configuration: %w", err)
 	}
 
-	adminAPIClientsFactory := adminapi.NewClientFactoryForWorkspace(c.KongWorkspace, c.KongAdminAPIConfig, c.KongAdminToken)
+	adminAPIClientsFactory := adminapi.NewClientFactoryForWorkspace(c.KongWorkspace, c.KongAdminAPIConfig, c.KongAdminToken).
+		WithBasePath(c.KongAdminBasePath)
 
 	setupLog.Info("Getting the kong admin api client configuration")
 	initialKongClients, err := c.adminAPIClients(
@@ -114,13 +115,25 @@ func Run(
 	kongSemVersion := semver.Version{Major: v.Major(), Minor: v.Minor(), Patch: v.Patch()}
 
 	kongConfig := sendconfig.Config{
-		Version:            kongSemVersion,
-		InMemory:           dbMode.IsDBLessMode(),
-		Concurrency:        c.Concurrency,
-		FilterTags:         c.FilterTags,
-		SkipCACertificates: c.SkipCACertificates,
-		EnableReverseSync:  c.EnableReverseSync,
-		ExpressionRoutes:   dpconf.ShouldEnableExpressionRoutes(routerFlavor),
+		Version:               kongSemVersion,
+		InMemory:              dbMode.IsDBLessMode(),
+		Concurrency:           c.Concurrency,
+		FilterTags:            c.FilterTags,
+		ExternalEntityTags:    c.ExternalEntityTags,
+		SkipCACertificates:    c.SkipCACertificates,
+		EnableReverseSync:     c.EnableReverseSync,
+		ValidatePluginSchemas: c.ValidatePluginSchemas,
+		ValidateCertificates:  c.ValidateCertificates,
+		ExpressionRoutes:      dpconf.ShouldEnableExpressionRoutes(routerFlavor),
+	}
+	if c.MaxConcurrentDumps > 0 {
+		kongConfig.DumpLimiter = sendconfig.NewDumpLimiter(c.MaxConcurrentDumps)
+	}
+	if !c.AllowEmptyConfig {
+		kongConfig.EmptyConfigGuard = sendconfig.NewEmptyConfigGuard(c.EmptyConfigMinFraction)
+	}
+	if c.InitialHashGracePeriod > 0 {
+		kongConfig.InitialHashGracePeriod = sendconfig.NewInitialHashGracePeriod(c.InitialHashGracePeriod)
 	}
 	kongConfig.Init(ctx, setupLog, initialKongClients)
 
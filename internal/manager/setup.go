@@ -298,6 +298,10 @@ func (c *Config) adminAPIClients(
 	addresses := c.KongAdminURLs
 	clients := make([]*adminapi.Client, 0, len(addresses))
 	for _, address := range addresses {
+		address, err := adminapi.AdminURLWithBasePath(address, c.KongAdminBasePath)
+		if err != nil {
+			return nil, err
+		}
 		cl, err := adminapi.NewKongClientForWorkspace(ctx, address, c.KongWorkspace, httpclient)
 		if err != nil {
 			return nil, err
@@ -1,13 +1,17 @@
 package metrics
 
 import (
+	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
 	"sync"
 	"time"
 
+	deckutils "github.com/kong/deck/utils"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samber/lo"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
@@ -15,9 +19,13 @@ import (
 
 // descriptions of these metrics are found below, where their help text is set in NewCtrlFuncMetrics()
 
+// CtrlFuncMetrics holds the controller's metrics. Its Record* methods are no-ops when called on a nil
+// *CtrlFuncMetrics, hence nil can be passed wherever metrics are not needed (e.g. in embedded or test usages).
 type CtrlFuncMetrics struct {
 	ConfigPushCount *prometheus.CounterVec
 
+	ConfigPushRetryCount *prometheus.CounterVec
+
 	ConfigPushBrokenResources *prometheus.GaugeVec
 
 	TranslationCount *prometheus.CounterVec
@@ -26,7 +34,45 @@ type CtrlFuncMetrics struct {
 
 	ConfigPushDuration *prometheus.HistogramVec
 
+	ConfigPushSizeBytes *prometheus.HistogramVec
+
 	ConfigPushSuccessTime *prometheus.GaugeVec
+
+	ConfigHashInitialCount *prometheus.CounterVec
+
+	ConfigHashInitialGraceCount *prometheus.CounterVec
+
+	ConfigSyncSkippedCount *prometheus.CounterVec
+
+	ConfigDriftDetectedCount *prometheus.CounterVec
+
+	ConfigForcedResyncCount *prometheus.CounterVec
+
+	ConfigPushCircuitOpenCount *prometheus.CounterVec
+
+	ConfigSyncPausedCount *prometheus.CounterVec
+
+	ConfigPushCoalescedCount *prometheus.CounterVec
+
+	ConfigPushLastAppliedSHA *prometheus.GaugeVec
+
+	ConfigPushPhaseDuration *prometheus.HistogramVec
+
+	ConfigPushProtocol *prometheus.GaugeVec
+
+	ConfigEntityCount *prometheus.GaugeVec
+
+	ConfigPushEntityTypeDuration *prometheus.HistogramVec
+
+	ConfigPushQueueWaitDuration *prometheus.HistogramVec
+
+	ConfigDumpWaitDuration *prometheus.HistogramVec
+
+	ConfigPluginNullsRemovedCount *prometheus.CounterVec
+
+	ConfigPushTimeout *prometheus.GaugeVec
+
+	ConfigPushRateLimitedCount *prometheus.CounterVec
 }
 
 const (
@@ -52,12 +98,51 @@ const (
 )
 
 const (
-	// FailureReasonConflict indicates that the config push failed due to configuration conflicts.
+	// FailureReasonConflict indicates that the config push failed due to Kong's Admin API reporting conflicts
+	// (409 Conflict), e.g. caused by concurrent changes of Kong's configuration.
 	FailureReasonConflict string = "conflict"
 
+	// FailureReasonConfigConflict indicates that the config push failed due to conflicts found by decK while
+	// transforming the configuration, i.e. due to a problem with the generated configuration.
+	FailureReasonConfigConflict string = "config_conflict"
+
 	// FailureReasonNetwork indicates that the config push failed due to network issues.
 	FailureReasonNetwork string = "network"
 
+	// FailureReasonValidation indicates that the config push failed due to Kong rejecting the configuration
+	// as not passing schema validation.
+	FailureReasonValidation string = "validation"
+
+	// FailureReasonAuth indicates that the config push failed due to Kong rejecting the controller's credentials.
+	FailureReasonAuth string = "auth"
+
+	// FailureReasonTransform indicates that the config push failed due to an error in a custom transformation
+	// of the configuration.
+	FailureReasonTransform string = "transform"
+
+	// FailureReasonTimeout indicates that the config push failed due to exceeding its deadline.
+	FailureReasonTimeout string = "timeout"
+
+	// FailureReasonCanceled indicates that the config push failed due to its context being canceled
+	// (e.g. on the controller's shutdown).
+	FailureReasonCanceled string = "canceled"
+
+	// FailureReasonTooLarge indicates that the config push was aborted due to the configuration exceeding
+	// the maximum allowed size.
+	FailureReasonTooLarge string = "too_large"
+
+	// FailureReasonVerification indicates that the config push succeeded, but Kong reported a configuration hash
+	// not matching the pushed configuration when it was read back.
+	FailureReasonVerification string = "verification"
+
+	// FailureReasonPreconditionFailed indicates that the conditional config push was rejected due to Kong's
+	// configuration having been modified concurrently (see deckerrors.ConfigPreconditionFailedError).
+	FailureReasonPreconditionFailed string = "precondition_failed"
+
+	// FailureReasonUnavailable indicates that the config push failed due to the Admin API being temporarily
+	// unavailable (503 Service Unavailable), e.g. while Kong is restarting during a rolling upgrade.
+	FailureReasonUnavailable string = "unavailable"
+
 	// FailureReasonOther indicates that the config push failed due to other reasons.
 	FailureReasonOther string = "other"
 
@@ -71,12 +156,54 @@ const (
 )
 
 const (
-	MetricNameConfigPushCount            = "ingress_controller_configuration_push_count"
-	MetricNameConfigPushBrokenResources  = "ingress_controller_configuration_push_broken_resource_count"
-	MetricNameConfigPushSuccessTime      = "ingress_controller_configuration_push_last_successful"
-	MetricNameTranslationCount           = "ingress_controller_translation_count"
-	MetricNameTranslationBrokenResources = "ingress_controller_translation_broken_resource_count"
-	MetricNameConfigPushDuration         = "ingress_controller_configuration_push_duration_milliseconds"
+	// PhaseKey defines the name of the metric label indicating the phase of a configuration push.
+	PhaseKey string = "phase"
+
+	// PhasePreparation is the phase of a configuration push in which the configuration is prepared
+	// (transformed, hashed, serialized, etc.) before being sent to the dataplane.
+	PhasePreparation string = "preparation"
+
+	// PhasePush is the phase of a configuration push in which the configuration is sent to the dataplane.
+	PhasePush string = "push"
+)
+
+const (
+	// EntityTypeKey defines the name of the metric label indicating the type of Kong entities.
+	EntityTypeKey string = "entity_type"
+)
+
+const (
+	// ConfigSHAKey defines the name of the metric label holding a hex encoded configuration SHA.
+	ConfigSHAKey string = "config_sha"
+)
+
+const (
+	MetricNameConfigPushCount               = "ingress_controller_configuration_push_count"
+	MetricNameConfigPushRetryCount          = "ingress_controller_configuration_push_retry_count"
+	MetricNameConfigPushBrokenResources     = "ingress_controller_configuration_push_broken_resource_count"
+	MetricNameConfigPushSuccessTime         = "ingress_controller_configuration_push_last_successful"
+	MetricNameConfigHashInitialCount        = "ingress_controller_configuration_hash_initial_count"
+	MetricNameConfigHashInitialGraceCount   = "ingress_controller_configuration_hash_initial_grace_count"
+	MetricNameConfigSyncSkippedCount        = "ingress_controller_configuration_sync_skipped_count"
+	MetricNameConfigDriftDetectedCount      = "ingress_controller_configuration_drift_detected_count"
+	MetricNameConfigForcedResyncCount       = "ingress_controller_configuration_forced_resync_count"
+	MetricNameConfigPushCircuitOpenCount    = "ingress_controller_configuration_push_circuit_open_count"
+	MetricNameConfigSyncPausedCount         = "ingress_controller_configuration_sync_paused_count"
+	MetricNameConfigPushCoalescedCount      = "ingress_controller_configuration_push_coalesced_count"
+	MetricNameConfigPushLastAppliedSHA      = "ingress_controller_configuration_push_last_applied_sha"
+	MetricNameTranslationCount              = "ingress_controller_translation_count"
+	MetricNameTranslationBrokenResources    = "ingress_controller_translation_broken_resource_count"
+	MetricNameConfigPushDuration            = "ingress_controller_configuration_push_duration_milliseconds"
+	MetricNameConfigPushSizeBytes           = "ingress_controller_configuration_push_size_bytes"
+	MetricNameConfigPushPhaseDuration       = "ingress_controller_configuration_push_phase_duration_milliseconds"
+	MetricNameConfigPushProtocol            = "ingress_controller_configuration_push_protocol"
+	MetricNameConfigEntityCount             = "ingress_controller_configuration_entity_count"
+	MetricNameConfigPushEntityTypeDuration  = "ingress_controller_configuration_push_entity_type_duration_milliseconds"
+	MetricNameConfigPushQueueWaitDuration   = "ingress_controller_configuration_push_queue_wait_duration_milliseconds"
+	MetricNameConfigDumpWaitDuration        = "ingress_controller_configuration_dump_wait_duration_milliseconds"
+	MetricNameConfigPluginNullsRemovedCount = "ingress_controller_configuration_plugin_config_nulls_removed_count"
+	MetricNameConfigPushTimeout             = "ingress_controller_configuration_push_timeout_milliseconds"
+	MetricNameConfigPushRateLimitedCount    = "ingress_controller_configuration_push_rate_limited_count"
 )
 
 var _lock sync.Mutex
@@ -96,17 +223,33 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 					"`%s` describes the configuration protocol (`%s` or `%s`) in use. "+
 					"`%s` describes whether there were unrecoverable errors (`%s`) or not (`%s`). "+
 					"`%s` is populated in case of `%s=\"%s\"` and describes the reason of failure "+
-					"(one of `%s`, `%s`, `%s`).",
+					"(one of `%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`).",
 				DataplaneKey,
 				ProtocolKey, ProtocolDBLess, ProtocolDeck,
 				SuccessKey, SuccessFalse, SuccessTrue,
 				FailureReasonKey, SuccessKey, SuccessFalse,
-				FailureReasonConflict, FailureReasonNetwork, FailureReasonOther,
+				FailureReasonConflict, FailureReasonConfigConflict, FailureReasonValidation, FailureReasonAuth, FailureReasonNetwork,
+				FailureReasonTimeout, FailureReasonTransform, FailureReasonCanceled, FailureReasonTooLarge, FailureReasonVerification,
+				FailureReasonPreconditionFailed, FailureReasonUnavailable, FailureReasonOther,
 			),
 		},
 		[]string{SuccessKey, ProtocolKey, FailureReasonKey, DataplaneKey},
 	)
 
+	controllerMetrics.ConfigPushRetryCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigPushRetryCount,
+			Help: fmt.Sprintf(
+				"Count of configuration push retries caused by transient errors. "+
+					"`%s` describes the dataplane that was the target of configuration push. "+
+					"`%s` describes the configuration protocol (`%s` or `%s`) in use.",
+				DataplaneKey,
+				ProtocolKey, ProtocolDBLess, ProtocolDeck,
+			),
+		},
+		[]string{ProtocolKey, DataplaneKey},
+	)
+
 	controllerMetrics.ConfigPushBrokenResources = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: MetricNameConfigPushBrokenResources,
@@ -147,14 +290,34 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 				"How long it took to push the configuration to Kong, in milliseconds. "+
 					"`%s` describes the dataplane that was the target of configuration push. "+
 					"`%s` describes the configuration protocol (`%s` or `%s`) in use. "+
-					"`%s` describes whether there were unrecoverable errors (`%s`) or not (`%s`).",
+					"`%s` describes whether there were unrecoverable errors (`%s`) or not (`%s`). "+
+					"`%s` is populated in case of `%s=\"%s\"` and describes the reason of failure "+
+					"(see `%s` for possible values).",
 				DataplaneKey,
 				ProtocolKey, ProtocolDBLess, ProtocolDeck,
 				SuccessKey, SuccessFalse, SuccessTrue,
+				FailureReasonKey, SuccessKey, SuccessFalse,
+				MetricNameConfigPushCount,
 			),
 			Buckets: prometheus.ExponentialBuckets(100, 1.33, 30),
 		},
-		[]string{SuccessKey, ProtocolKey, DataplaneKey},
+		[]string{SuccessKey, ProtocolKey, FailureReasonKey, DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPushSizeBytes = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigPushSizeBytes,
+			Help: fmt.Sprintf(
+				"Size of the configuration pushed to Kong, in bytes. "+
+					"`%s` describes the dataplane that was the target of configuration push. "+
+					"`%s` describes the configuration protocol (`%s` or `%s`) in use.",
+				DataplaneKey,
+				ProtocolKey, ProtocolDBLess, ProtocolDeck,
+			),
+			// 1KiB to 32MiB.
+			Buckets: prometheus.ExponentialBuckets(1024, 2, 16),
+		},
+		[]string{ProtocolKey, DataplaneKey},
 	)
 
 	controllerMetrics.ConfigPushSuccessTime = prometheus.NewGaugeVec(
@@ -168,20 +331,306 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		[]string{DataplaneKey},
 	)
 
+	controllerMetrics.ConfigHashInitialCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigHashInitialCount,
+			Help: fmt.Sprintf(
+				"Count of times a dataplane was detected to report the initial (empty) configuration hash "+
+					"despite being already configured (e.g. after a restart), forcing a full configuration re-sync. "+
+					"`%s` describes the dataplane that reported the initial configuration hash.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigHashInitialGraceCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigHashInitialGraceCount,
+			Help: fmt.Sprintf(
+				"Count of configuration re-syncs of dataplanes reporting the initial (empty) configuration hash "+
+					"deferred due to the grace period letting a single controller replica re-sync a restarted dataplane. "+
+					"`%s` describes the dataplane that reported the initial configuration hash.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigSyncSkippedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigSyncSkippedCount,
+			Help: fmt.Sprintf(
+				"Count of configuration syncs skipped as the configuration's SHA matched the one last applied to "+
+					"a dataplane and the dataplane was found to still have it. Compared with `%s`, it tells how many "+
+					"configuration pushes the SHA check saves. "+
+					"`%s` describes the dataplane that the configuration sync was skipped for.",
+				MetricNameConfigPushCount,
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigDriftDetectedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigDriftDetectedCount,
+			Help: fmt.Sprintf(
+				"Count of times a dataplane's configuration was found to diverge from the configuration last applied "+
+					"to it (e.g. due to changes made directly through the Admin API). "+
+					"`%s` describes the dataplane whose configuration drifted.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigForcedResyncCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigForcedResyncCount,
+			Help: fmt.Sprintf(
+				"Count of periodic forced configuration resyncs, i.e. configuration syncs performed despite "+
+					"the configuration's SHA matching the one last applied to a dataplane. "+
+					"`%s` describes the dataplane that the configuration was resynced to.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPushCircuitOpenCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigPushCircuitOpenCount,
+			Help: fmt.Sprintf(
+				"Count of configuration pushes skipped due to the circuit being open after a dataplane repeatedly "+
+					"rejected configuration due to conflicts. "+
+					"`%s` describes the dataplane that the configuration push was skipped for.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigSyncPausedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigSyncPausedCount,
+			Help: fmt.Sprintf(
+				"Count of configuration syncs skipped due to configuration syncing being paused. "+
+					"`%s` describes the dataplane that the configuration sync was skipped for.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPushCoalescedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigPushCoalescedCount,
+			Help: fmt.Sprintf(
+				"Count of intermediate configurations dropped without being pushed as they were superseded by "+
+					"a newer configuration while waiting for an in-flight push to complete. "+
+					"`%s` describes the dataplane that the configuration push was dropped for.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPushLastAppliedSHA = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigPushLastAppliedSHA,
+			Help: fmt.Sprintf(
+				"Info metric (always 1) describing the configuration last successfully applied to a dataplane. "+
+					"`%s` describes the dataplane that was the target of the configuration push. "+
+					"`%s` is the hex encoded SHA of the applied configuration.",
+				DataplaneKey,
+				ConfigSHAKey,
+			),
+		},
+		[]string{DataplaneKey, ConfigSHAKey},
+	)
+
+	controllerMetrics.ConfigPushPhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigPushPhaseDuration,
+			Help: fmt.Sprintf(
+				"How long a phase of configuration push took, in milliseconds. "+
+					"`%s` describes the dataplane that was the target of configuration push. "+
+					"`%s` describes the phase: `%s` (transforming, hashing and serializing the configuration) "+
+					"or `%s` (sending the configuration to the dataplane).",
+				DataplaneKey,
+				PhaseKey, PhasePreparation, PhasePush,
+			),
+			Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{PhaseKey, DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPushProtocol = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigPushProtocol,
+			Help: fmt.Sprintf(
+				"Info metric (always 1) describing the protocol used for the last configuration push to a dataplane. "+
+					"`%s` describes the dataplane that was the target of the configuration push. "+
+					"`%s` describes the configuration protocol (`%s` or `%s`) in use.",
+				DataplaneKey,
+				ProtocolKey, ProtocolDBLess, ProtocolDeck,
+			),
+		},
+		[]string{DataplaneKey, ProtocolKey},
+	)
+
+	controllerMetrics.ConfigEntityCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigEntityCount,
+			Help: fmt.Sprintf(
+				"The number of Kong entities in the configuration last successfully applied to a dataplane. "+
+					"`%s` describes the dataplane that was the target of the configuration push. "+
+					"`%s` describes the type of entities (e.g. `services`, `routes` or `plugins`).",
+				DataplaneKey,
+				EntityTypeKey,
+			),
+		},
+		[]string{DataplaneKey, EntityTypeKey},
+	)
+
+	controllerMetrics.ConfigPushEntityTypeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigPushEntityTypeDuration,
+			Help: fmt.Sprintf(
+				"Approximate time spent on syncing Kong entities of a type during a configuration push in DB mode, "+
+					"in milliseconds. "+
+					"`%s` describes the dataplane that was the target of the configuration push. "+
+					"`%s` describes the kind of entities as named by decK (e.g. `service`, `route` or `plugin`).",
+				DataplaneKey,
+				EntityTypeKey,
+			),
+			Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{DataplaneKey, EntityTypeKey},
+	)
+
+	controllerMetrics.ConfigPushQueueWaitDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigPushQueueWaitDuration,
+			Help: fmt.Sprintf(
+				"Approximate time Kong entity changes waited for a free sync worker during a configuration push "+
+					"in DB mode, in milliseconds. Long waits mean pushes are bottlenecked on the sync concurrency "+
+					"rather than on Kong, so increasing the concurrency would help. "+
+					"`%s` describes the dataplane that was the target of the configuration push.",
+				DataplaneKey,
+			),
+			Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigDumpWaitDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigDumpWaitDuration,
+			Help: fmt.Sprintf(
+				"Time a configuration push in DB mode waited for its turn to dump the current configuration from Kong "+
+					"due to the limit of concurrent dumps, in milliseconds. "+
+					"`%s` describes the dataplane that was the target of the configuration push.",
+				DataplaneKey,
+			),
+			Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPluginNullsRemovedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigPluginNullsRemovedCount,
+			Help: fmt.Sprintf(
+				"Count of null fields removed from plugins' configs before pushing configuration in DB-less mode "+
+					"(where Kong rejects them). Non-zero values may signal configuration being generated incorrectly. "+
+					"`%s` describes the dataplane that was the target of the configuration push.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPushTimeout = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigPushTimeout,
+			Help: fmt.Sprintf(
+				"Timeout of the last configuration push to a dataplane computed from recent push durations, "+
+					"in milliseconds (only when the adaptive push timeout is enabled). "+
+					"`%s` describes the dataplane that was the target of the configuration push.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigPushRateLimitedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigPushRateLimitedCount,
+			Help: fmt.Sprintf(
+				"Count of configuration pushes skipped due to exceeding the push rate limit of a dataplane. "+
+					"`%s` describes the dataplane that the configuration push was skipped for.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushRetryCount)
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushBrokenResources)
 	metrics.Registry.Unregister(controllerMetrics.TranslationCount)
 	metrics.Registry.Unregister(controllerMetrics.TranslationBrokenResources)
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushDuration)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushSizeBytes)
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushSuccessTime)
+	metrics.Registry.Unregister(controllerMetrics.ConfigHashInitialCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigHashInitialGraceCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigSyncSkippedCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigDriftDetectedCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigForcedResyncCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushCircuitOpenCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigSyncPausedCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushCoalescedCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushLastAppliedSHA)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushPhaseDuration)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushProtocol)
+	metrics.Registry.Unregister(controllerMetrics.ConfigEntityCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushEntityTypeDuration)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushQueueWaitDuration)
+	metrics.Registry.Unregister(controllerMetrics.ConfigDumpWaitDuration)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPluginNullsRemovedCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushTimeout)
+	metrics.Registry.Unregister(controllerMetrics.ConfigPushRateLimitedCount)
 
 	metrics.Registry.MustRegister(
 		controllerMetrics.ConfigPushCount,
+		controllerMetrics.ConfigPushRetryCount,
 		controllerMetrics.ConfigPushBrokenResources,
 		controllerMetrics.TranslationCount,
 		controllerMetrics.TranslationBrokenResources,
 		controllerMetrics.ConfigPushDuration,
+		controllerMetrics.ConfigPushSizeBytes,
 		controllerMetrics.ConfigPushSuccessTime,
+		controllerMetrics.ConfigHashInitialCount,
+		controllerMetrics.ConfigHashInitialGraceCount,
+		controllerMetrics.ConfigSyncSkippedCount,
+		controllerMetrics.ConfigDriftDetectedCount,
+		controllerMetrics.ConfigForcedResyncCount,
+		controllerMetrics.ConfigPushCircuitOpenCount,
+		controllerMetrics.ConfigSyncPausedCount,
+		controllerMetrics.ConfigPushCoalescedCount,
+		controllerMetrics.ConfigPushLastAppliedSHA,
+		controllerMetrics.ConfigPushPhaseDuration,
+		controllerMetrics.ConfigPushProtocol,
+		controllerMetrics.ConfigEntityCount,
+		controllerMetrics.ConfigPushEntityTypeDuration,
+		controllerMetrics.ConfigPushQueueWaitDuration,
+		controllerMetrics.ConfigDumpWaitDuration,
+		controllerMetrics.ConfigPluginNullsRemovedCount,
+		controllerMetrics.ConfigPushTimeout,
+		controllerMetrics.ConfigPushRateLimitedCount,
 	)
 
 	return controllerMetrics
@@ -189,6 +638,9 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 
 // RecordPushSuccess records a successful configuration push.
 func (c *CtrlFuncMetrics) RecordPushSuccess(p Protocol, d time.Duration, dataplane string) {
+	if c == nil {
+		return
+	}
 	dpOpt := withDataplane(dataplane)
 	c.recordPushCount(p, dpOpt)
 	c.recordPushDuration(p, d, dpOpt)
@@ -198,14 +650,249 @@ func (c *CtrlFuncMetrics) RecordPushSuccess(p Protocol, d time.Duration, datapla
 
 // RecordPushFailure records a failed configuration push.
 func (c *CtrlFuncMetrics) RecordPushFailure(p Protocol, d time.Duration, dataplane string, count int, err error) {
+	if c == nil {
+		return
+	}
 	dpOpt := withDataplane(dataplane)
 	c.recordPushCount(p, dpOpt, withError(err))
-	c.recordPushDuration(p, d, dpOpt, withFailure())
+	c.recordPushDuration(p, d, dpOpt, withError(err))
 	c.recordPushBrokenResources(count, dpOpt)
 }
 
+// RecordPushRetry records a retry of a configuration push.
+func (c *CtrlFuncMetrics) RecordPushRetry(p Protocol, dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushRetryCount.With(prometheus.Labels{
+		ProtocolKey:  string(p),
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordPushSize records the size of a pushed configuration.
+func (c *CtrlFuncMetrics) RecordPushSize(p Protocol, sizeBytes int, dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushSizeBytes.With(prometheus.Labels{
+		ProtocolKey:  string(p),
+		DataplaneKey: dataplane,
+	}).Observe(float64(sizeBytes))
+}
+
+// RecordPushPhaseDuration records the duration of a configuration push phase (PhasePreparation or PhasePush).
+func (c *CtrlFuncMetrics) RecordPushPhaseDuration(phase string, d time.Duration, dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushPhaseDuration.With(prometheus.Labels{
+		PhaseKey:     phase,
+		DataplaneKey: dataplane,
+	}).Observe(float64(d) / float64(time.Millisecond))
+}
+
+// RecordPushEntityTypeDurations records the time spent on syncing entities of every type (keyed by decK's entity kind)
+// during a configuration push to a dataplane.
+func (c *CtrlFuncMetrics) RecordPushEntityTypeDurations(durations map[string]time.Duration, dataplane string) {
+	if c == nil {
+		return
+	}
+	for entityType, d := range durations {
+		c.ConfigPushEntityTypeDuration.With(prometheus.Labels{
+			DataplaneKey:  dataplane,
+			EntityTypeKey: entityType,
+		}).Observe(float64(d) / float64(time.Millisecond))
+	}
+}
+
+// RecordPushQueueWaitDurations records approximate times entity changes waited for a free sync worker during
+// a configuration push in DB mode.
+func (c *CtrlFuncMetrics) RecordPushQueueWaitDurations(durations []time.Duration, dataplane string) {
+	if c == nil {
+		return
+	}
+	for _, d := range durations {
+		c.ConfigPushQueueWaitDuration.With(prometheus.Labels{
+			DataplaneKey: dataplane,
+		}).Observe(float64(d) / float64(time.Millisecond))
+	}
+}
+
+// RecordDumpWaitDuration records the time a configuration push waited for its turn to dump the current configuration.
+func (c *CtrlFuncMetrics) RecordDumpWaitDuration(d time.Duration, dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigDumpWaitDuration.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Observe(float64(d) / float64(time.Millisecond))
+}
+
+// RecordPluginConfigNullsRemoved records null fields removed from plugins' configs before a configuration push.
+func (c *CtrlFuncMetrics) RecordPluginConfigNullsRemoved(count int, dataplane string) {
+	if c == nil || count == 0 {
+		return
+	}
+	c.ConfigPluginNullsRemovedCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Add(float64(count))
+}
+
+// RecordPushTimeout records the timeout computed for a configuration push to a dataplane.
+func (c *CtrlFuncMetrics) RecordPushTimeout(d time.Duration, dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushTimeout.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Set(float64(d) / float64(time.Millisecond))
+}
+
+// RecordLastAppliedConfigSHA records the SHA of the configuration successfully applied to a dataplane,
+// replacing the previously recorded one.
+func (c *CtrlFuncMetrics) RecordLastAppliedConfigSHA(sha []byte, dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushLastAppliedSHA.DeletePartialMatch(prometheus.Labels{
+		DataplaneKey: dataplane,
+	})
+	c.ConfigPushLastAppliedSHA.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+		ConfigSHAKey: hex.EncodeToString(sha),
+	}).Set(1)
+}
+
+// RecordPushProtocol records the protocol used for configuration pushes to a dataplane,
+// replacing the previously recorded one.
+func (c *CtrlFuncMetrics) RecordPushProtocol(p Protocol, dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushProtocol.DeletePartialMatch(prometheus.Labels{
+		DataplaneKey: dataplane,
+	})
+	c.ConfigPushProtocol.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+		ProtocolKey:  string(p),
+	}).Set(1)
+}
+
+// RecordConfigEntityCounts records numbers of entities (keyed by their type) in the configuration applied
+// to a dataplane.
+func (c *CtrlFuncMetrics) RecordConfigEntityCounts(counts map[string]int, dataplane string) {
+	if c == nil {
+		return
+	}
+	for entityType, count := range counts {
+		c.ConfigEntityCount.With(prometheus.Labels{
+			DataplaneKey:  dataplane,
+			EntityTypeKey: entityType,
+		}).Set(float64(count))
+	}
+}
+
+// RecordConfigHashInitial records a dataplane reporting the initial configuration hash
+// while the controller has already pushed configuration to it.
+func (c *CtrlFuncMetrics) RecordConfigHashInitial(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigHashInitialCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigHashInitialGrace records a re-sync of a dataplane reporting the initial configuration hash
+// deferred due to the grace period.
+func (c *CtrlFuncMetrics) RecordConfigHashInitialGrace(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigHashInitialGraceCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigSyncSkipped records a configuration sync skipped due to the configuration not having changed
+// since it was last applied to the dataplane.
+func (c *CtrlFuncMetrics) RecordConfigSyncSkipped(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigSyncSkippedCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigDriftDetected records a dataplane's configuration found to diverge from the one last applied to it.
+func (c *CtrlFuncMetrics) RecordConfigDriftDetected(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigDriftDetectedCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigForcedResync records a periodic forced configuration resync to a dataplane.
+func (c *CtrlFuncMetrics) RecordConfigForcedResync(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigForcedResyncCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigPushCircuitOpen records a configuration push skipped due to the circuit being open for a dataplane.
+func (c *CtrlFuncMetrics) RecordConfigPushCircuitOpen(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushCircuitOpenCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigPushRateLimited records a configuration push skipped due to exceeding the push rate limit
+// of a dataplane.
+func (c *CtrlFuncMetrics) RecordConfigPushRateLimited(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushRateLimitedCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigSyncPaused records a configuration sync skipped due to configuration syncing being paused.
+func (c *CtrlFuncMetrics) RecordConfigSyncPaused(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigSyncPausedCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
+// RecordConfigPushCoalesced records an intermediate configuration dropped without being pushed as it was superseded
+// by a newer one.
+func (c *CtrlFuncMetrics) RecordConfigPushCoalesced(dataplane string) {
+	if c == nil {
+		return
+	}
+	c.ConfigPushCoalescedCount.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+	}).Inc()
+}
+
 // RecordTranslationSuccess records a successful configuration translation.
 func (c *CtrlFuncMetrics) RecordTranslationSuccess() {
+	if c == nil {
+		return
+	}
 	c.TranslationCount.With(prometheus.Labels{
 		SuccessKey: SuccessTrue,
 	}).Inc()
@@ -213,6 +900,9 @@ func (c *CtrlFuncMetrics) RecordTranslationSuccess() {
 
 // RecordTranslationFailure records a failed configuration translation.
 func (c *CtrlFuncMetrics) RecordTranslationFailure() {
+	if c == nil {
+		return
+	}
 	c.TranslationCount.With(prometheus.Labels{
 		SuccessKey: SuccessFalse,
 	}).Inc()
@@ -220,6 +910,9 @@ func (c *CtrlFuncMetrics) RecordTranslationFailure() {
 
 // RecordTranslationBrokenResources records the number of resources failing translation.
 func (c *CtrlFuncMetrics) RecordTranslationBrokenResources(count int) {
+	if c == nil {
+		return
+	}
 	c.TranslationBrokenResources.Set(float64(count))
 }
 
@@ -227,14 +920,7 @@ type recordOption func(prometheus.Labels) prometheus.Labels
 
 func withError(err error) recordOption {
 	return func(l prometheus.Labels) prometheus.Labels {
-		l[FailureReasonKey] = pushFailureReason(err)
-		l[SuccessKey] = SuccessFalse
-		return l
-	}
-}
-
-func withFailure() recordOption {
-	return func(l prometheus.Labels) prometheus.Labels {
+		l[FailureReasonKey] = PushFailureReason(err)
 		l[SuccessKey] = SuccessFalse
 		return l
 	}
@@ -249,7 +935,7 @@ func withDataplane(dataplane string) recordOption {
 
 func (c *CtrlFuncMetrics) recordPushCount(p Protocol, opts ...recordOption) {
 	labels := prometheus.Labels{
-		// although this is hardcoded to true here, the withError or withFailure opt function will flip it to false
+		// although this is hardcoded to true here, the withError opt function will flip it to false
 		SuccessKey:       SuccessTrue,
 		ProtocolKey:      string(p),
 		FailureReasonKey: "",
@@ -264,9 +950,10 @@ func (c *CtrlFuncMetrics) recordPushCount(p Protocol, opts ...recordOption) {
 
 func (c *CtrlFuncMetrics) recordPushDuration(p Protocol, d time.Duration, opts ...recordOption) {
 	labels := prometheus.Labels{
-		// although this is hardcoded to true here, the withError or withFailure opt function will flip it to false
-		SuccessKey:  SuccessTrue,
-		ProtocolKey: string(p),
+		// although this is hardcoded to true here, the withError opt function will flip it to false
+		SuccessKey:       SuccessTrue,
+		ProtocolKey:      string(p),
+		FailureReasonKey: "",
 	}
 
 	for _, opt := range opts {
@@ -296,17 +983,74 @@ func (c *CtrlFuncMetrics) recordPushSuccessTime(opts ...recordOption) {
 	c.ConfigPushSuccessTime.With(labels).SetToCurrentTime()
 }
 
-// pushFailureReason extracts config push failure reason from an error returned
+// PushFailureReason extracts config push failure reason (one of FailureReason* constants) from an error returned
 // from sendconfig's onUpdateInMemoryMode or onUpdateDBMode.
-func pushFailureReason(err error) string {
+func PushFailureReason(err error) string {
+	if errors.Is(err, deckerrors.ContentTransformError{}) {
+		return FailureReasonTransform
+	}
+
+	if errors.Is(err, deckerrors.ConfigTooLargeError{}) {
+		return FailureReasonTooLarge
+	}
+
+	if errors.Is(err, deckerrors.ConfigVerificationError{}) {
+		return FailureReasonVerification
+	}
+
+	if isContextErr(err, context.DeadlineExceeded) {
+		return FailureReasonTimeout
+	}
+
+	// Checked before network errors as requests aborted due to cancellation are reported as such.
+	if isContextErr(err, context.Canceled) {
+		return FailureReasonCanceled
+	}
+
 	var netErr net.Error
 	if errors.As(err, &netErr) {
 		return FailureReasonNetwork
 	}
 
-	if deckerrors.IsConflictErr(err) {
+	if errors.Is(err, deckerrors.ConfigPreconditionFailedError{}) {
+		return FailureReasonPreconditionFailed
+	}
+
+	if deckerrors.IsConfigConflictErr(err) {
+		return FailureReasonConfigConflict
+	}
+
+	if deckerrors.IsAPIConflictErr(err) {
 		return FailureReasonConflict
 	}
 
+	if deckerrors.IsValidationErr(err) {
+		return FailureReasonValidation
+	}
+
+	if deckerrors.IsAuthErr(err) {
+		return FailureReasonAuth
+	}
+
+	if deckerrors.IsUnavailableErr(err) {
+		return FailureReasonUnavailable
+	}
+
 	return FailureReasonOther
 }
+
+// isContextErr tells whether an error was caused by a context's error (context.DeadlineExceeded or context.Canceled).
+func isContextErr(err error, contextErr error) bool {
+	if errors.Is(err, contextErr) {
+		return true
+	}
+
+	var deckErrArray deckutils.ErrArray
+	if errors.As(err, &deckErrArray) {
+		return lo.ContainsBy(deckErrArray.Errors, func(err error) bool {
+			return isContextErr(err, contextErr)
+		})
+	}
+
+	return false
+}
@@ -1,15 +1,18 @@
 package metrics
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
 	deckutils "github.com/kong/deck/utils"
 	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
@@ -24,6 +27,34 @@ func TestNewCtrlFuncMetricsDoesNotPanicWhenCalledTwice(t *testing.T) {
 	})
 }
 
+func TestNilCtrlFuncMetricsRecordingIsNoop(t *testing.T) {
+	var m *CtrlFuncMetrics
+	require.NotPanics(t, func() {
+		m.RecordPushSuccess(ProtocolDBLess, time.Second, "https://kong:8444")
+		m.RecordPushFailure(ProtocolDBLess, time.Second, "https://kong:8444", 1, errors.New("failure"))
+		m.RecordPushRetry(ProtocolDBLess, "https://kong:8444")
+		m.RecordPushSize(ProtocolDBLess, 1024, "https://kong:8444")
+		m.RecordPushPhaseDuration(PhasePush, time.Second, "https://kong:8444")
+		m.RecordLastAppliedConfigSHA([]byte("sha"), "https://kong:8444")
+		m.RecordPushProtocol(ProtocolDBLess, "https://kong:8444")
+		m.RecordConfigEntityCounts(map[string]int{"services": 1}, "https://kong:8444")
+		m.RecordPushEntityTypeDurations(map[string]time.Duration{"service": time.Second}, "https://kong:8444")
+		m.RecordPushQueueWaitDurations([]time.Duration{time.Second}, "https://kong:8444")
+		m.RecordConfigHashInitial("https://kong:8444")
+		m.RecordConfigSyncSkipped("https://kong:8444")
+		m.RecordConfigDriftDetected("https://kong:8444")
+		m.RecordConfigForcedResync("https://kong:8444")
+		m.RecordConfigPushCircuitOpen("https://kong:8444")
+		m.RecordConfigSyncPaused("https://kong:8444")
+		m.RecordConfigPushCoalesced("https://kong:8444")
+		m.RecordDumpWaitDuration(time.Second, "https://kong:8444")
+		m.RecordPluginConfigNullsRemoved(1, "https://kong:8444")
+		m.RecordTranslationSuccess()
+		m.RecordTranslationFailure()
+		m.RecordTranslationBrokenResources(1)
+	})
+}
+
 func TestRecordPush(t *testing.T) {
 	m := NewCtrlFuncMetrics()
 	t.Run("recording push success works", func(t *testing.T) {
@@ -37,6 +68,116 @@ func TestRecordPush(t *testing.T) {
 				fmt.Errorf("custom error"))
 		})
 	})
+	t.Run("recording push size works", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			m.RecordPushSize(ProtocolDeck, 2048, "https://10.0.0.1:8080")
+		})
+	})
+	t.Run("recording push retry works", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			m.RecordPushRetry(ProtocolDBLess, "https://10.0.0.1:8080")
+		})
+	})
+	t.Run("recording push phase duration works", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			m.RecordPushPhaseDuration(PhasePreparation, time.Millisecond, "https://10.0.0.1:8080")
+			m.RecordPushPhaseDuration(PhasePush, time.Second, "https://10.0.0.1:8080")
+		})
+	})
+	t.Run("recording config hash initial works", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			m.RecordConfigHashInitial("https://10.0.0.1:8080")
+		})
+	})
+}
+
+func TestRecordPushDuration(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	const dataplane = "https://10.0.0.1:8080"
+
+	m.RecordPushSuccess(ProtocolDBLess, time.Millisecond, dataplane)
+	m.RecordPushFailure(ProtocolDBLess, time.Second, dataplane, 0, deckerrors.ConfigConflictError{Err: errors.New("conflict")})
+	m.RecordPushFailure(ProtocolDBLess, time.Millisecond, dataplane, 0, &net.OpError{Op: "dial", Err: errors.New("refused")})
+
+	require.Equal(t, 3, testutil.CollectAndCount(m.ConfigPushDuration))
+	for _, tc := range []struct {
+		success       string
+		failureReason string
+	}{
+		{success: SuccessTrue, failureReason: ""},
+		{success: SuccessFalse, failureReason: FailureReasonConfigConflict},
+		{success: SuccessFalse, failureReason: FailureReasonNetwork},
+	} {
+		require.Equal(t, 1, m.ConfigPushDuration.DeletePartialMatch(map[string]string{
+			SuccessKey:       tc.success,
+			FailureReasonKey: tc.failureReason,
+		}), "expected push duration recorded with success=%q and failure reason=%q", tc.success, tc.failureReason)
+	}
+}
+
+func TestRecordLastAppliedConfigSHA(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	const (
+		dataplane      = "https://10.0.0.1:8080"
+		otherDataplane = "https://10.0.0.2:8080"
+	)
+
+	m.RecordLastAppliedConfigSHA([]byte{0x01, 0x02}, dataplane)
+	m.RecordLastAppliedConfigSHA([]byte{0x01, 0x02}, otherDataplane)
+	m.RecordLastAppliedConfigSHA([]byte{0xab, 0xcd}, dataplane)
+
+	require.Equal(t, 2, testutil.CollectAndCount(m.ConfigPushLastAppliedSHA),
+		"only the last applied SHA should be recorded per dataplane")
+	require.Equal(t, float64(1), testutil.ToFloat64(m.ConfigPushLastAppliedSHA.WithLabelValues(dataplane, "abcd")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.ConfigPushLastAppliedSHA.WithLabelValues(otherDataplane, "0102")))
+}
+
+func TestRecordPushProtocol(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	const (
+		dataplane      = "https://10.0.0.1:8080"
+		otherDataplane = "https://10.0.0.2:8080"
+	)
+
+	m.RecordPushProtocol(ProtocolDeck, dataplane)
+	m.RecordPushProtocol(ProtocolDBLess, otherDataplane)
+	m.RecordPushProtocol(ProtocolDBLess, dataplane)
+
+	require.Equal(t, 2, testutil.CollectAndCount(m.ConfigPushProtocol),
+		"only the last used protocol should be recorded per dataplane")
+	require.Equal(t, float64(1), testutil.ToFloat64(m.ConfigPushProtocol.WithLabelValues(dataplane, string(ProtocolDBLess))))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.ConfigPushProtocol.WithLabelValues(otherDataplane, string(ProtocolDBLess))))
+}
+
+func TestRecordConfigEntityCounts(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	const dataplane = "https://10.0.0.1:8080"
+
+	m.RecordConfigEntityCounts(map[string]int{"services": 3, "routes": 5}, dataplane)
+	m.RecordConfigEntityCounts(map[string]int{"services": 2, "routes": 0}, dataplane)
+
+	require.Equal(t, 2, testutil.CollectAndCount(m.ConfigEntityCount))
+	require.Equal(t, float64(2), testutil.ToFloat64(m.ConfigEntityCount.WithLabelValues(dataplane, "services")))
+	require.Equal(t, float64(0), testutil.ToFloat64(m.ConfigEntityCount.WithLabelValues(dataplane, "routes")))
+}
+
+func TestRecordPushEntityTypeDurations(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	const dataplane = "https://10.0.0.1:8080"
+
+	m.RecordPushEntityTypeDurations(map[string]time.Duration{"service": time.Second, "route": 2 * time.Second}, dataplane)
+	m.RecordPushEntityTypeDurations(map[string]time.Duration{"service": time.Second}, dataplane)
+
+	require.Equal(t, 2, testutil.CollectAndCount(m.ConfigPushEntityTypeDuration))
+}
+
+func TestRecordPushQueueWaitDurations(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	const dataplane = "https://10.0.0.1:8080"
+
+	m.RecordPushQueueWaitDurations([]time.Duration{0, time.Millisecond, 2 * time.Millisecond}, dataplane)
+
+	require.Equal(t, 1, testutil.CollectAndCount(m.ConfigPushQueueWaitDuration))
 }
 
 func TestRecordTranslation(t *testing.T) {
@@ -57,6 +198,7 @@ func TestRecordTranslation(t *testing.T) {
 
 func TestPushFailureReason(t *testing.T) {
 	apiConflictErr := kong.NewAPIError(http.StatusConflict, "conflict api error")
+	apiValidationErr := kong.NewAPIError(http.StatusBadRequest, "schema violation")
 	networkErr := net.UnknownNetworkError("network error")
 	genericError := errors.New("generic error")
 
@@ -83,12 +225,12 @@ func TestPushFailureReason(t *testing.T) {
 		{
 			name:           "deck_config_conflict_error_empty",
 			err:            deckerrors.ConfigConflictError{},
-			expectedReason: FailureReasonConflict,
+			expectedReason: FailureReasonConfigConflict,
 		},
 		{
 			name:           "deck_config_conflict_error_with_generic_error",
 			err:            deckerrors.ConfigConflictError{Err: genericError},
-			expectedReason: FailureReasonConflict,
+			expectedReason: FailureReasonConfigConflict,
 		},
 		{
 			name:           "deck_err_array_with_api_conflict_error",
@@ -100,6 +242,51 @@ func TestPushFailureReason(t *testing.T) {
 			err:            fmt.Errorf("wrapped: %w", deckutils.ErrArray{Errors: []error{apiConflictErr}}),
 			expectedReason: FailureReasonConflict,
 		},
+		{
+			name:           "api_validation_error",
+			err:            apiValidationErr,
+			expectedReason: FailureReasonValidation,
+		},
+		{
+			name:           "api_validation_error_wrapped",
+			err:            fmt.Errorf("wrapped validation api err: %w", apiValidationErr),
+			expectedReason: FailureReasonValidation,
+		},
+		{
+			name:           "config_status_validation_error",
+			err:            fmt.Errorf("wrapped: %w", deckerrors.ConfigStatusError{StatusCode: http.StatusBadRequest, Err: genericError}),
+			expectedReason: FailureReasonValidation,
+		},
+		{
+			name:           "deck_err_array_with_api_validation_error",
+			err:            deckutils.ErrArray{Errors: []error{genericError, apiValidationErr}},
+			expectedReason: FailureReasonValidation,
+		},
+		{
+			name:           "api_unauthorized_error",
+			err:            kong.NewAPIError(http.StatusUnauthorized, "unauthorized"),
+			expectedReason: FailureReasonAuth,
+		},
+		{
+			name:           "deck_err_array_with_api_forbidden_error",
+			err:            deckutils.ErrArray{Errors: []error{genericError, kong.NewAPIError(http.StatusForbidden, "forbidden")}},
+			expectedReason: FailureReasonAuth,
+		},
+		{
+			name:           "api_service_unavailable_error",
+			err:            kong.NewAPIError(http.StatusServiceUnavailable, "service unavailable"),
+			expectedReason: FailureReasonUnavailable,
+		},
+		{
+			name:           "deck_err_array_with_api_service_unavailable_error",
+			err:            deckutils.ErrArray{Errors: []error{genericError, kong.NewAPIError(http.StatusServiceUnavailable, "service unavailable")}},
+			expectedReason: FailureReasonUnavailable,
+		},
+		{
+			name:           "admin_api_unavailable_error",
+			err:            fmt.Errorf("wrapped: %w", deckerrors.ConfigStatusError{StatusCode: http.StatusServiceUnavailable, Err: genericError}),
+			expectedReason: FailureReasonUnavailable,
+		},
 		{
 			name:           "deck_err_array_with_generic_error",
 			err:            deckutils.ErrArray{Errors: []error{genericError}},
@@ -115,6 +302,62 @@ func TestPushFailureReason(t *testing.T) {
 			err:            networkErr,
 			expectedReason: FailureReasonNetwork,
 		},
+		{
+			name: "unix_socket_network_error",
+			err: fmt.Errorf("failed posting new config to /config: %w", &url.Error{
+				Op: "Post", URL: "unix:///usr/local/kong/admin.sock/config", Err: &net.OpError{
+					Op: "dial", Net: "unix", Addr: &net.UnixAddr{Name: "/usr/local/kong/admin.sock", Net: "unix"},
+					Err: errors.New("connect: no such file or directory"),
+				},
+			}),
+			expectedReason: FailureReasonNetwork,
+		},
+		{
+			name:           "content_transform_error",
+			err:            fmt.Errorf("wrapped: %w", deckerrors.ContentTransformError{Err: genericError}),
+			expectedReason: FailureReasonTransform,
+		},
+		{
+			name:           "config_too_large_error",
+			err:            fmt.Errorf("wrapped: %w", deckerrors.ConfigTooLargeError{Size: 2, Limit: 1}),
+			expectedReason: FailureReasonTooLarge,
+		},
+		{
+			name:           "config_verification_error",
+			err:            fmt.Errorf("wrapped: %w", deckerrors.ConfigVerificationError{Expected: "a", Actual: "b"}),
+			expectedReason: FailureReasonVerification,
+		},
+		{
+			name:           "config_precondition_failed_error",
+			err:            fmt.Errorf("wrapped: %w", deckerrors.ConfigPreconditionFailedError{Expected: "a", Err: genericError}),
+			expectedReason: FailureReasonPreconditionFailed,
+		},
+		{
+			name:           "deadline_exceeded",
+			err:            fmt.Errorf("failed posting new config to /config: %w", context.DeadlineExceeded),
+			expectedReason: FailureReasonTimeout,
+		},
+		{
+			name: "deadline_exceeded_in_deck_err_array",
+			err: deckutils.ErrArray{Errors: []error{
+				fmt.Errorf("failed to sync all entities: %w", context.DeadlineExceeded),
+			}},
+			expectedReason: FailureReasonTimeout,
+		},
+		{
+			name: "canceled_request",
+			err: fmt.Errorf("failed posting new config to /config: %w", &url.Error{
+				Op: "Post", URL: "http://localhost:8001/config", Err: context.Canceled,
+			}),
+			expectedReason: FailureReasonCanceled,
+		},
+		{
+			name: "canceled_in_deck_err_array",
+			err: deckutils.ErrArray{Errors: []error{
+				fmt.Errorf("failed to sync all entities: %w", context.Canceled),
+			}},
+			expectedReason: FailureReasonCanceled,
+		},
 		{
 			name:           "network_error_wrapped_in_deck_config_conflict_error",
 			err:            deckerrors.ConfigConflictError{Err: networkErr},
@@ -127,7 +370,7 @@ func TestPushFailureReason(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			reason := pushFailureReason(tc.err)
+			reason := PushFailureReason(tc.err)
 			require.Equal(t, tc.expectedReason, reason)
 		})
 	}
@@ -84,7 +84,7 @@ func TestUpdateStrategyInMemory_PropagatesResourcesErrors(t *testing.T) {
 	}
 
 	require.Eventually(t, func() bool {
-		err, resourceErrors, parseErr := sut.Update(ctx, faultyConfig)
+		_, err, resourceErrors, parseErr := sut.Update(ctx, faultyConfig)
 		if err == nil {
 			t.Logf("expected error: %v", err)
 			return false
@@ -60,7 +60,7 @@ func TestTranslatorsGoldenTestsOutputs(t *testing.T) {
 		require.NoError(t, err)
 
 		require.Eventually(t, func() bool {
-			err, resourceErrors, parseErr := sut.Update(ctx, sendconfig.ContentWithHash{Content: content})
+			_, err, resourceErrors, parseErr := sut.Update(ctx, sendconfig.ContentWithHash{Content: content})
 			if err != nil {
 				t.Logf("error: %v", err)
 				return false